@@ -19,6 +19,7 @@ import (
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -26,30 +27,128 @@ import (
 	"github.com/go-redis/redis/v8"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
-	"blunderbuss/pkg/circuitbreaker"
-	"blunderbuss/pkg/correlation"
-	"blunderbuss/pkg/logging"
-	"blunderbuss/pkg/metrics"
-	"blunderbuss/pkg/retry"
+	"stockfish-scale/pkg/circuitbreaker"
+	"stockfish-scale/pkg/correlation"
+	"stockfish-scale/pkg/correlation/middleware"
+	"stockfish-scale/pkg/keys"
+	"stockfish-scale/pkg/logging"
+	"stockfish-scale/pkg/metrics"
+	"stockfish-scale/pkg/metrics/tenantstats"
+	"stockfish-scale/pkg/movecache"
+	"stockfish-scale/pkg/redisconn"
+	"stockfish-scale/pkg/resilience"
+	"stockfish-scale/pkg/retry"
+	"stockfish-scale/pkg/scheduler"
+)
+
+const (
+	// streamHeartbeatInterval is how often streamHandler writes an SSE
+	// comment line to an otherwise-idle connection, so proxies and load
+	// balancers with shorter idle timeouts don't close it mid-search.
+	streamHeartbeatInterval = 15 * time.Second
+
+	// streamEventBufferSize bounds the channel between a streamHandler
+	// connection's pub/sub receiver goroutine and its SSE writer loop, so a
+	// client too slow to keep up drops older progress frames instead of the
+	// receiver blocking (and piling up unbounded memory) behind it.
+	streamEventBufferSize = 32
+
+	// tenantHeader is the request header a caller sets to attribute its
+	// requests to a tenant for api_request_duration_seconds,
+	// api_requests_total, api_successful_operations_total, and
+	// cost_efficiency_ratio. A request without it is tracked under the
+	// empty-string tenant rather than rejected.
+	tenantHeader = "X-Tenant-ID"
 )
 
 var (
-	rdb                 *redis.Client
+	rdb                 redis.UniversalClient
 	ctx                 = context.Background()
 	metricsCollector    *metrics.MetricsCollector
 	logger              logging.Logger
+	auditWriter         *logging.StdoutAuditWriter
 	correlationIDGen    *correlation.IDGenerator
 	redisCircuitBreaker *circuitbreaker.CircuitBreaker
 	retryConfig         retry.Config
-	
+	jobPublishPolicy    *resilience.Composed // retry.WithRetry(redisCircuitBreaker.Call(XAdd)), composed (requirement 3.6, 4.3)
+	jobFlushPolicy      *resilience.Composed // redisCircuitBreaker.Call(retry.WithRetry(pipe.Exec)), composed (requirement 3.6, 4.3)
+	periodicScheduler   *scheduler.Manager
+	moveCache           *movecache.Cache
+	tenantAggregator    *tenantstats.Aggregator
+
 	// Queue depth variance tracking (Requirement 5.7)
 	queueDepthHistory []queueDepthSnapshot
 	queueDepthMu      sync.RWMutex
+
+	// Optional client-side command pipelining for job enqueue
+	// (REDIS_PIPE_PERIOD), an alternative to the per-request XADD in
+	// publishJobWithRetry for bursty load.
+	jobPipeliningEnabled   bool
+	jobPipePeriod          time.Duration
+	jobPipeliner           redis.Pipeliner
+	jobPipelineFlushed     chan struct{}
+	jobPipelineMu          sync.Mutex
+	jobPipelineStopChan    chan struct{}
+	jobPipelineStoppedChan chan struct{}
 )
 
 type queueDepthSnapshot struct {
 	timestamp time.Time
 	depth     int64
+	pending   int64
+}
+
+// totalQueueDepth sums XLEN across every shard's job stream, since the queue
+// is now partitioned behind pkg/keys rather than living under one
+// "stockfish:jobs" key.
+func totalQueueDepth(ctx context.Context) int64 {
+	var total int64
+	for _, queueKey := range keys.AllJobsQueues() {
+		depth, err := rdb.XLen(ctx, queueKey).Result()
+		if err != nil {
+			continue
+		}
+		total += depth
+	}
+	return total
+}
+
+// totalPendingCount sums each shard's XPENDING summary count: entries a
+// worker's XREADGROUP delivered but hasn't XACKed yet, either still being
+// processed or abandoned and awaiting the reclaim reaper.
+func totalPendingCount(ctx context.Context) int64 {
+	var total int64
+	for _, queueKey := range keys.AllJobsQueues() {
+		summary, err := rdb.XPending(ctx, queueKey, keys.JobsGroup).Result()
+		if err != nil {
+			continue
+		}
+		total += summary.Count
+	}
+	return total
+}
+
+// oldestPendingLag returns how long the longest-idle pending entry across
+// every shard's stream has gone unacknowledged, the consumer-group lag
+// operators care about when a worker dies mid-job.
+func oldestPendingLag(ctx context.Context) time.Duration {
+	var maxIdle time.Duration
+	for _, queueKey := range keys.AllJobsQueues() {
+		entries, err := rdb.XPendingExt(ctx, &redis.XPendingExtArgs{
+			Stream: queueKey,
+			Group:  keys.JobsGroup,
+			Start:  "-",
+			End:    "+",
+			Count:  1,
+		}).Result()
+		if err != nil || len(entries) == 0 {
+			continue
+		}
+		if entries[0].Idle > maxIdle {
+			maxIdle = entries[0].Idle
+		}
+	}
+	return maxIdle
 }
 
 type MoveRequest struct {
@@ -93,19 +192,42 @@ type JobResult struct {
 	Ponder        string `json:"ponder,omitempty"`
 	Info          string `json:"info,omitempty"`
 	Error         string `json:"error,omitempty"`
+	CancelledAt   string `json:"cancelled_at,omitempty"`
+}
+
+// ProgressEvent is one incremental update a worker PUBLISHes on
+// keys.ProgressChannel(jobID) while a job is in flight. streamHandler
+// subscribes and forwards each one as an SSE frame of the matching
+// event: info/bestmove/error. Repeated in worker/main.go for clarity: don't
+// change the shape without updating both.
+type ProgressEvent struct {
+	JobID         string `json:"job_id"`
+	CorrelationID string `json:"correlation_id,omitempty"`
+	Type          string `json:"type"` // "info", "bestmove", or "error"
+	Line          string `json:"line,omitempty"`
+	BestMove      string `json:"bestmove,omitempty"`
+	Ponder        string `json:"ponder,omitempty"`
+	Error         string `json:"error,omitempty"`
 }
 
 // HealthStatus represents the health check response
 type HealthStatus struct {
-	Status         string `json:"status"`
-	RedisConnected bool   `json:"redis_connected"`
-	QueueDepth     int64  `json:"queue_depth"`
-	Timestamp      string `json:"timestamp"`
+	Status             string  `json:"status"`
+	RedisConnected     bool    `json:"redis_connected"`
+	QueueDepth         int64   `json:"queue_depth"`
+	PendingCount       int64   `json:"pending_count"`
+	ConsumerLagSeconds float64 `json:"consumer_lag_seconds"`
+	Timestamp          string  `json:"timestamp"`
 }
 
 func main() {
-	// Initialize structured logger (requirement 8.7)
-	logger = logging.NewLogger("api")
+	// Initialize structured logger (requirement 8.7), additionally fanning
+	// every entry out to a tamper-evident audit trail separate from the
+	// operational slog.Handler - stdout JSON today, a stand-in for the
+	// Kafka/S3/webhook writer a real deployment would register instead.
+	auditWriter = &logging.StdoutAuditWriter{}
+	auditMux := logging.NewChanneledLogMux(logging.WriterConfig{Writer: auditWriter})
+	logger = logging.NewLoggerWithMux("api", auditMux)
 	logger.Info("Starting API service")
 
 	// Initialize metrics collector (requirement 1.8)
@@ -122,7 +244,7 @@ func main() {
 			"from": from.String(),
 			"to":   to.String(),
 		}).Info("Redis circuit breaker state changed")
-		
+
 		// Update circuit breaker state metrics (requirement 3.8)
 		var stateValue float64
 		switch to {
@@ -139,29 +261,98 @@ func main() {
 	// Initialize retry config (requirement 4.3)
 	retryConfig = retry.RedisPublishRetryConfig()
 
-	// Initialize Redis client
-	redisAddr := getenv("REDIS_ADDR", "redis:6379")
-	rdb = redis.NewClient(&redis.Options{
-		Addr: redisAddr,
-	})
-	_, err := rdb.Ping(ctx).Result()
+	// Compose the retry+circuit-breaker chains publishJobWithRetry and
+	// flushJobPipeline used to nest by hand, preserving each call site's
+	// existing policy order (requirement 3.6, 4.3).
+	jobPublishPolicy = resilience.NewPolicy(
+		resilience.RetryPolicy{Config: retryConfig},
+		resilience.CircuitBreakerPolicy{Breaker: redisCircuitBreaker},
+	)
+	jobFlushPolicy = resilience.NewPolicy(
+		resilience.CircuitBreakerPolicy{Breaker: redisCircuitBreaker},
+		resilience.RetryPolicy{Config: retryConfig},
+	)
+
+	// Initialize Redis client. pkg/redisconn reads REDIS_MODE so single,
+	// Sentinel, and Cluster deployments all return a redis.UniversalClient
+	// without changing the rdb.Ping/XAdd/XLen calls below.
+	redisConf := redisconn.ConfigFromEnv()
+	redisConf.OnFailover = func(masterName string) {
+		logger.WithField("sentinel_master", masterName).Info("Redis Sentinel failover detected")
+		metricsCollector.IncrementRedisFailovers("api", masterName)
+	}
+	var err error
+	rdb, err = redisconn.New(redisConf)
+	if err != nil {
+		logger.Error("Invalid Redis configuration", err)
+		os.Exit(1)
+	}
+	redisAddr := redisConf.Addr
+	_, err = rdb.Ping(ctx).Result()
 	if err != nil {
 		logger.Error("Warning: Redis connection failed", err)
 	} else {
-		logger.WithField("redis_addr", redisAddr).Info("Connected to Redis")
+		logger.WithField("redis_mode", string(redisConf.Mode)).Info("Connected to Redis")
+	}
+
+	// Start the periodic job scheduler. Every API replica runs the tick
+	// loop, but the Redis lease in acquireLeadership ensures only one of
+	// them actually enqueues due templates at a time.
+	instanceID := fmt.Sprintf("api-%s-%d", getenv("HOSTNAME", "unknown"), os.Getpid())
+	periodicScheduler = scheduler.NewManager(rdb, metricsCollector, logger, instanceID)
+	periodicScheduler.Start()
+
+	// Initialize the two-tier move cache: an in-process LRU in front of the
+	// Redis tier the worker populates on job completion.
+	moveCache = movecache.New(rdb, metricsCollector, movecache.DefaultConfig())
+
+	// Initialize the per-tenant cost-tracking aggregator (MAX_TENANT_CARDINALITY,
+	// default tenantstats.DefaultMaxTenantCardinality, folds overflow tenants
+	// into tenantstats.OverflowTenant so a runaway API key can't blow up
+	// Prometheus label cardinality).
+	maxTenantCardinality := tenantstats.DefaultMaxTenantCardinality
+	if v := os.Getenv("MAX_TENANT_CARDINALITY"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			maxTenantCardinality = n
+		} else {
+			logger.WithField("value", v).Warn("Invalid MAX_TENANT_CARDINALITY, using default")
+		}
+	}
+	tenantAggregator = tenantstats.NewAggregator(metricsCollector, maxTenantCardinality, 0)
+	tenantAggregator.Start()
+
+	// Initialize optional pipelined job enqueue (opt-in, off by default so
+	// low-latency deployments keep the existing per-request XADD path)
+	initJobPipeliner()
+	if jobPipeliningEnabled {
+		go runJobPipelineFlusher()
 	}
 
 	// Create HTTP server with graceful shutdown
 	mux := http.NewServeMux()
-	
+
 	// Metrics endpoint (requirement 1.8)
 	mux.Handle("/metrics", promhttp.Handler())
-	
+
 	// Enhanced health check endpoint (requirement 6.1)
 	mux.HandleFunc("/healthz", healthCheckHandler)
-	
+
 	// Move endpoint with correlation ID middleware
-	mux.HandleFunc("/move", correlationIDMiddleware(moveHandler))
+	mux.Handle("/move", middleware.HTTPMiddleware(http.HandlerFunc(moveHandler), correlationIDGen))
+
+	// SSE progress streaming endpoint: GET/POST /move/stream
+	mux.Handle("/move/stream", middleware.HTTPMiddleware(http.HandlerFunc(streamHandler), correlationIDGen))
+
+	// Job cancellation endpoint: DELETE /jobs/{id}
+	mux.Handle("/jobs/", middleware.HTTPMiddleware(http.HandlerFunc(cancelJobHandler), correlationIDGen))
+
+	// Move cache invalidation endpoint: DELETE /cache?fen=...&elo=...&movetime_ms=...
+	mux.Handle("/cache", middleware.HTTPMiddleware(http.HandlerFunc(cacheInvalidateHandler), correlationIDGen))
+
+	// Periodic job template endpoints: GET/POST /periodic, DELETE
+	// /periodic/{id}, POST /periodic/{id}/pause, POST /periodic/{id}/resume
+	mux.Handle("/periodic", middleware.HTTPMiddleware(http.HandlerFunc(periodicTemplatesHandler), correlationIDGen))
+	mux.Handle("/periodic/", middleware.HTTPMiddleware(http.HandlerFunc(periodicTemplateHandler), correlationIDGen))
 
 	addr := ":8080"
 	server := &http.Server{
@@ -175,39 +366,25 @@ func main() {
 			"addr":       addr,
 			"redis_addr": redisAddr,
 		}).Info("API listening")
-		
+
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			logger.Error("Server error", err)
 			os.Exit(1)
 		}
 	}()
-	
+
 	// Start queue depth variance tracking (Requirement 5.7)
 	go trackQueueDepthVariance()
 
-	// Graceful shutdown (requirement 6.6)
-	gracefulShutdown(server)
-}
-
-// correlationIDMiddleware generates or extracts correlation ID (requirement 8.1, 8.2)
-func correlationIDMiddleware(next http.HandlerFunc) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		// Extract or generate correlation ID
-		correlationID := r.Header.Get("X-Correlation-ID")
-		if correlationID == "" {
-			correlationID = correlationIDGen.Generate()
-		}
-
-		// Store in context (requirement 8.2)
-		ctx := correlation.WithID(r.Context(), correlationID)
-		r = r.WithContext(ctx)
+	// Periodically report the audit log writer's backlog
+	go trackAuditLogBufferSize()
 
-		// Add to response headers (requirement 8.5)
-		w.Header().Set("X-Correlation-ID", correlationID)
+	// Start the result keywatcher so waitForResult is notified of
+	// completions instead of polling for them.
+	go startResultKeywatcher()
 
-		// Call next handler
-		next(w, r)
-	}
+	// Graceful shutdown (requirement 6.6)
+	gracefulShutdown(server)
 }
 
 // healthCheckHandler implements enhanced health check (requirement 6.1, 6.4)
@@ -218,9 +395,13 @@ func healthCheckHandler(w http.ResponseWriter, r *http.Request) {
 
 	redisOk := rdb.Ping(checkCtx).Err() == nil
 	queueDepth := int64(0)
+	pendingCount := int64(0)
+	consumerLag := time.Duration(0)
 
 	if redisOk {
-		queueDepth, _ = rdb.LLen(checkCtx, "stockfish:jobs").Result()
+		queueDepth = totalQueueDepth(checkCtx)
+		pendingCount = totalPendingCount(checkCtx)
+		consumerLag = oldestPendingLag(checkCtx)
 	}
 
 	status := "healthy"
@@ -231,10 +412,12 @@ func healthCheckHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	healthStatus := HealthStatus{
-		Status:         status,
-		RedisConnected: redisOk,
-		QueueDepth:     queueDepth,
-		Timestamp:      time.Now().Format(time.RFC3339),
+		Status:             status,
+		RedisConnected:     redisOk,
+		QueueDepth:         queueDepth,
+		PendingCount:       pendingCount,
+		ConsumerLagSeconds: consumerLag.Seconds(),
+		Timestamp:          time.Now().Format(time.RFC3339),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -247,6 +430,7 @@ func moveHandler(w http.ResponseWriter, r *http.Request) {
 	// Get correlation ID from context
 	correlationID, _ := correlation.FromContext(r.Context())
 	reqLogger := logger.WithCorrelationID(correlationID)
+	tenant := tenantFromRequest(r)
 
 	// Start latency tracking (requirement 1.1)
 	startTime := time.Now()
@@ -270,7 +454,7 @@ func moveHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	if r.Method != http.MethodPost {
 		http.Error(w, "POST only", http.StatusMethodNotAllowed)
-		metricsCollector.IncrementRequestCounter("405")
+		metricsCollector.IncrementRequestCounter("405", tenant)
 		// Log request completion with error (requirement 8.6, 8.7, 8.8)
 		duration := time.Since(startTime)
 		reqLogger.WithFields(map[string]interface{}{
@@ -278,7 +462,7 @@ func moveHandler(w http.ResponseWriter, r *http.Request) {
 			"duration_ms": duration.Milliseconds(),
 			"error":       "method not allowed",
 		}).Error("Request completed with error", nil)
-		metricsCollector.RecordRequestDuration("/move", "405", duration)
+		metricsCollector.RecordRequestDuration("/move", "405", tenant, duration)
 		return
 	}
 
@@ -291,8 +475,8 @@ func moveHandler(w http.ResponseWriter, r *http.Request) {
 			"duration_ms": duration.Milliseconds(),
 		}).Error("Bad JSON in request", err)
 		http.Error(w, "bad json: "+err.Error(), http.StatusBadRequest)
-		metricsCollector.IncrementRequestCounter("400")
-		metricsCollector.RecordRequestDuration("/move", "400", duration)
+		metricsCollector.IncrementRequestCounter("400", tenant)
+		metricsCollector.RecordRequestDuration("/move", "400", tenant, duration)
 		return
 	}
 	if req.FEN == "" {
@@ -303,8 +487,8 @@ func moveHandler(w http.ResponseWriter, r *http.Request) {
 			"duration_ms": duration.Milliseconds(),
 		}).Error("Missing FEN in request", nil)
 		http.Error(w, "missing fen", http.StatusBadRequest)
-		metricsCollector.IncrementRequestCounter("400")
-		metricsCollector.RecordRequestDuration("/move", "400", duration)
+		metricsCollector.IncrementRequestCounter("400", tenant)
+		metricsCollector.RecordRequestDuration("/move", "400", tenant, duration)
 		return
 	}
 
@@ -322,6 +506,31 @@ func moveHandler(w http.ResponseWriter, r *http.Request) {
 		req.MoveTimeMs = 1000
 	}
 
+	// Consult the two-tier move cache before queuing a job, unless the
+	// caller opted out with ?nocache=1 (requirement: identical queries are
+	// common for opening-book positions and don't need a fresh engine run).
+	noCache := r.URL.Query().Get("nocache") == "1"
+	cacheKey := movecache.Key(req.FEN, req.Elo, req.MoveTimeMs)
+	if !noCache {
+		if entry, tier, ok := moveCache.Get(r.Context(), cacheKey); ok {
+			duration := time.Since(startTime)
+			reqLogger.WithFields(map[string]interface{}{
+				"cache_tier":  tier,
+				"status":      200,
+				"duration_ms": duration.Milliseconds(),
+			}).Info("Served move from cache")
+			metricsCollector.IncrementRequestCounter("200", tenant)
+			metricsCollector.RecordRequestDuration("/move", "200", tenant, duration)
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(MoveResponse{
+				BestMove: entry.BestMove,
+				Ponder:   entry.Ponder,
+				Info:     entry.Info,
+			})
+			return
+		}
+	}
+
 	jobID := fmt.Sprintf("job_%d_%d", time.Now().UnixNano(), req.Elo)
 	job := Job{
 		JobID:         jobID,
@@ -333,10 +542,10 @@ func moveHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	reqLogger.WithFields(map[string]interface{}{
-		"job_id":       jobID,
-		"elo":          req.Elo,
-		"movetime_ms":  req.MoveTimeMs,
-		"fen_preview":  truncateString(req.FEN, 20),
+		"job_id":      jobID,
+		"elo":         req.Elo,
+		"movetime_ms": req.MoveTimeMs,
+		"fen_preview": truncateString(req.FEN, 20),
 	}).Info("Processing move request")
 
 	jobData, err := json.Marshal(job)
@@ -349,20 +558,20 @@ func moveHandler(w http.ResponseWriter, r *http.Request) {
 			"duration_ms": duration.Milliseconds(),
 		}).Error("Error marshaling job", err)
 		http.Error(w, "failed to serialize job", http.StatusInternalServerError)
-		metricsCollector.IncrementRequestCounter("500")
-		metricsCollector.RecordRequestDuration("/move", "500", duration)
+		metricsCollector.IncrementRequestCounter("500", tenant)
+		metricsCollector.RecordRequestDuration("/move", "500", tenant, duration)
 		return
 	}
 
 	// Publish job to Redis with circuit breaker and retry (requirements 3.6, 4.3)
-	err = publishJobWithRetry(r.Context(), jobData, correlationID, reqLogger)
+	err = publishJobWithRetry(r.Context(), jobID, jobData, correlationID, reqLogger)
 	if err != nil {
 		duration := time.Since(startTime)
 		// Check if circuit breaker is open (requirement 3.7)
 		if redisCircuitBreaker.IsOpen() {
 			sendCircuitBreakerError(w, correlationID, reqLogger)
-			metricsCollector.IncrementRequestCounter("503")
-			metricsCollector.RecordRequestDuration("/move", "503", duration)
+			metricsCollector.IncrementRequestCounter("503", tenant)
+			metricsCollector.RecordRequestDuration("/move", "503", tenant, duration)
 			return
 		}
 
@@ -373,14 +582,13 @@ func moveHandler(w http.ResponseWriter, r *http.Request) {
 			"duration_ms": duration.Milliseconds(),
 		}).Error("Error queuing job", err)
 		http.Error(w, "failed to queue job: "+err.Error(), http.StatusServiceUnavailable)
-		metricsCollector.IncrementRequestCounter("503")
-		metricsCollector.RecordRequestDuration("/move", "503", duration)
+		metricsCollector.IncrementRequestCounter("503", tenant)
+		metricsCollector.RecordRequestDuration("/move", "503", tenant, duration)
 		return
 	}
 
 	// Update queue depth metric (requirement 1.6)
-	queueDepth, _ := rdb.LLen(r.Context(), "stockfish:jobs").Result()
-	metricsCollector.SetQueueDepth(float64(queueDepth))
+	metricsCollector.SetQueueDepth(float64(totalQueueDepth(r.Context())))
 
 	timeout := time.Duration(req.MoveTimeMs+5000) * time.Millisecond
 	result, err := waitForResult(jobID, timeout, correlationID, reqLogger)
@@ -394,13 +602,31 @@ func moveHandler(w http.ResponseWriter, r *http.Request) {
 			"timeout_ms":  timeout.Milliseconds(),
 		}).Error("Job failed or timed out", err)
 		http.Error(w, "job timeout or error: "+err.Error(), http.StatusRequestTimeout)
-		metricsCollector.IncrementRequestCounter("408")
-		metricsCollector.RecordRequestDuration("/move", "408", duration)
+		metricsCollector.IncrementRequestCounter("408", tenant)
+		metricsCollector.RecordRequestDuration("/move", "408", tenant, duration)
 		return
 	}
 
-	// Record successful operation (requirement 5.1)
-	metricsCollector.IncrementSuccessfulOps()
+	// Record successful operation (requirement 5.1). Routed through
+	// tenantAggregator rather than calling IncrementSuccessfulOps directly so
+	// the per-tenant counter stays on the same bounded-cardinality, batched
+	// flush path as api_successful_operations_total's cost_efficiency_ratio
+	// sibling.
+	if tenantAggregator != nil {
+		tenantAggregator.Update(tenant, true, 0)
+	} else {
+		metricsCollector.IncrementSuccessfulOps(tenant)
+	}
+
+	// Populate the local cache tier with this fresh result; the Redis tier
+	// was already SETEX'd by the worker that computed it.
+	if !noCache {
+		moveCache.PutLocal(cacheKey, movecache.Entry{
+			BestMove: result.BestMove,
+			Ponder:   result.Ponder,
+			Info:     result.Info,
+		})
+	}
 
 	// Log request completion (requirement 8.6, 8.8)
 	duration := time.Since(startTime)
@@ -412,8 +638,8 @@ func moveHandler(w http.ResponseWriter, r *http.Request) {
 	}).Info("Request completed")
 
 	// Record metrics (requirement 1.5, 1.6)
-	metricsCollector.IncrementRequestCounter("200")
-	metricsCollector.RecordRequestDuration("/move", "200", duration)
+	metricsCollector.IncrementRequestCounter("200", tenant)
+	metricsCollector.RecordRequestDuration("/move", "200", tenant, duration)
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(MoveResponse{
@@ -423,45 +649,540 @@ func moveHandler(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// streamHandler handles GET /move/stream (query params fen/elo/movetime_ms)
+// or POST /move/stream (same JSON body as /move), keeping the connection
+// open and forwarding the job's incremental engine progress as Server-Sent
+// Events until a "bestmove"/"error" event arrives or the client disconnects.
+// Unlike moveHandler, it never consults the move cache: a cache hit has no
+// intermediate info lines to stream, so there's nothing this endpoint can
+// offer over a plain /move call in that case.
+func streamHandler(w http.ResponseWriter, r *http.Request) {
+	correlationID, _ := correlation.FromContext(r.Context())
+	reqLogger := logger.WithCorrelationID(correlationID)
+	tenant := tenantFromRequest(r)
+
+	enableCORS(&w, r)
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	var req MoveRequest
+	switch r.Method {
+	case http.MethodGet:
+		req.FEN = r.URL.Query().Get("fen")
+		req.Elo, _ = strconv.Atoi(r.URL.Query().Get("elo"))
+		req.MoveTimeMs, _ = strconv.Atoi(r.URL.Query().Get("movetime_ms"))
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "bad json: "+err.Error(), http.StatusBadRequest)
+			metricsCollector.IncrementRequestCounter("400", tenant)
+			return
+		}
+	default:
+		http.Error(w, "GET or POST only", http.StatusMethodNotAllowed)
+		metricsCollector.IncrementRequestCounter("405", tenant)
+		return
+	}
+
+	if req.FEN == "" {
+		http.Error(w, "missing fen", http.StatusBadRequest)
+		metricsCollector.IncrementRequestCounter("400", tenant)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		metricsCollector.IncrementRequestCounter("500", tenant)
+		return
+	}
+
+	// Validate and set defaults (same bounds as moveHandler)
+	if req.Elo == 0 {
+		req.Elo = 1600
+	}
+	if req.Elo < 1320 {
+		req.Elo = 1320
+	}
+	if req.Elo > 3190 {
+		req.Elo = 3190
+	}
+	if req.MoveTimeMs <= 0 {
+		req.MoveTimeMs = 1000
+	}
+
+	jobID := fmt.Sprintf("job_%d_%d", time.Now().UnixNano(), req.Elo)
+	job := Job{
+		JobID:         jobID,
+		CorrelationID: correlationID,
+		FEN:           req.FEN,
+		Elo:           req.Elo,
+		MaxTime:       req.MoveTimeMs,
+		CreatedAt:     time.Now().Format(time.RFC3339Nano),
+	}
+
+	jobData, err := json.Marshal(job)
+	if err != nil {
+		http.Error(w, "failed to serialize job", http.StatusInternalServerError)
+		metricsCollector.IncrementRequestCounter("500", tenant)
+		return
+	}
+
+	// Subscribe before enqueueing so a worker fast enough to finish before
+	// this handler registers never publishes into the void - the same
+	// ordering concern waitForResult's fallback GET protects against for
+	// the plain /move path, just not needed here since progress events
+	// aren't persisted anywhere for a late subscriber to fall back to.
+	pubsub := rdb.Subscribe(r.Context(), keys.ProgressChannel(jobID))
+	defer pubsub.Close()
+
+	if err := publishJobWithRetry(r.Context(), jobID, jobData, correlationID, reqLogger); err != nil {
+		if redisCircuitBreaker.IsOpen() {
+			sendCircuitBreakerError(w, correlationID, reqLogger)
+			metricsCollector.IncrementRequestCounter("503", tenant)
+			return
+		}
+		http.Error(w, "failed to queue job: "+err.Error(), http.StatusServiceUnavailable)
+		metricsCollector.IncrementRequestCounter("503", tenant)
+		return
+	}
+
+	reqLogger.WithField("job_id", jobID).Info("Streaming move request")
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	// Bounded so a slow client falling behind drops older buffered frames
+	// instead of blocking the pub/sub receiver (and letting deliveries pile
+	// up unboundedly in this goroutine's memory).
+	events := make(chan *redis.Message, streamEventBufferSize)
+	go func() {
+		for msg := range pubsub.Channel() {
+			select {
+			case events <- msg:
+			default:
+				select {
+				case <-events:
+				default:
+				}
+				events <- msg
+			}
+		}
+		close(events)
+	}()
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	timeout := time.NewTimer(time.Duration(req.MoveTimeMs+5000) * time.Millisecond)
+	defer timeout.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			reqLogger.WithField("job_id", jobID).Info("SSE client disconnected")
+			return
+
+		case <-timeout.C:
+			writeSSEEvent(w, flusher, "error", ProgressEvent{JobID: jobID, CorrelationID: correlationID, Type: "error", Error: "timeout waiting for job result"})
+			return
+
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+			flusher.Flush()
+
+		case msg, ok := <-events:
+			if !ok {
+				return
+			}
+			var event ProgressEvent
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+				continue
+			}
+			writeSSEEvent(w, flusher, event.Type, event)
+			if event.Type == "bestmove" || event.Type == "error" {
+				return
+			}
+		}
+	}
+}
+
+// writeSSEEvent writes one SSE frame (event: <name>\ndata: <json>\n\n) and
+// flushes it immediately so the client sees it without buffering delay.
+func writeSSEEvent(w http.ResponseWriter, flusher http.Flusher, eventName string, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", eventName, data)
+	flusher.Flush()
+}
+
 // publishJobWithRetry publishes job to Redis with circuit breaker and retry logic
-func publishJobWithRetry(ctx context.Context, jobData []byte, correlationID string, reqLogger logging.Logger) error {
+func publishJobWithRetry(ctx context.Context, jobID string, jobData []byte, correlationID string, reqLogger logging.Logger) error {
+	queueKey := keys.JobsQueue(keys.ShardForJobID(jobID))
+
+	// When REDIS_PIPE_PERIOD is set, skip the per-request XADD entirely:
+	// append to the shared pipeliner and wait for the next periodic flush to
+	// resolve this job's future (requirements 3.6, 4.3 still apply, just to
+	// the flush rather than the append).
+	if jobPipeliningEnabled {
+		return enqueueJobPipelined(ctx, queueKey, jobData)
+	}
+
 	var attemptNum int
-	
-	// Wrap with retry logic (requirement 4.3, 4.6)
-	err := retry.WithRetry(ctx, retryConfig, func() error {
+
+	// jobPublishPolicy retries (requirement 4.3, 4.6) each call through the
+	// Redis circuit breaker (requirement 3.6).
+	return jobPublishPolicy.Execute(ctx, func(ctx context.Context) error {
 		attemptNum++
-		
+
 		// Log retry attempts (requirement 4.6)
 		if attemptNum > 1 {
 			reqLogger.WithFields(map[string]interface{}{
-				"attempt":        attemptNum,
-				"max_attempts":   retryConfig.MaxAttempts,
+				"attempt":      attemptNum,
+				"max_attempts": retryConfig.MaxAttempts,
 			}).Info("Retrying Redis job publish")
-			
+
 			// Increment retry metrics (requirement 4.6)
 			metricsCollector.IncrementRetryAttempts("api", "redis_publish", strconv.Itoa(attemptNum))
 		}
-		
-		// Wrap with circuit breaker (requirement 3.6)
-		return redisCircuitBreaker.Call(func() error {
-			err := rdb.LPush(ctx, "stockfish:jobs", jobData).Err()
-			if err != nil {
-				// Increment circuit breaker failure metrics (requirement 3.8)
-				metricsCollector.IncrementCircuitBreakerFailures("redis", "api")
-			}
-			return err
-		})
+
+		err := rdb.XAdd(ctx, &redis.XAddArgs{
+			Stream: queueKey,
+			Values: map[string]interface{}{"job": jobData},
+		}).Err()
+		if err != nil {
+			// Increment circuit breaker failure metrics (requirement 3.8)
+			metricsCollector.IncrementCircuitBreakerFailures("redis", "api")
+		}
+		return err
 	})
-	
-	return err
+}
+
+// initJobPipeliner reads the opt-in job enqueue pipelining configuration
+// from REDIS_PIPE_PERIOD (e.g. "5ms"). Pipelining is off by default so
+// low-latency deployments keep today's one-XADD-per-request behavior.
+func initJobPipeliner() {
+	v := os.Getenv("REDIS_PIPE_PERIOD")
+	if v == "" {
+		return
+	}
+
+	period, err := time.ParseDuration(v)
+	if err != nil || period <= 0 {
+		logger.WithField("value", v).Warn("Invalid REDIS_PIPE_PERIOD, job enqueue pipelining disabled")
+		return
+	}
+
+	jobPipePeriod = period
+	jobPipeliner = rdb.Pipeline()
+	jobPipelineFlushed = make(chan struct{})
+	jobPipelineStopChan = make(chan struct{})
+	jobPipelineStoppedChan = make(chan struct{})
+	jobPipeliningEnabled = true
+
+	logger.WithField("pipe_period", jobPipePeriod.String()).Info("Pipelined job enqueue enabled")
+}
+
+// enqueueJobPipelined appends an XADD to the shared pipeliner and blocks
+// until the next tick (or shutdown) flushes it, returning that command's
+// result. The append itself is just a mutex-guarded slice push; retry and
+// circuit breaker wrapping happens once per flush in flushJobPipeline, not
+// once per enqueue.
+func enqueueJobPipelined(ctx context.Context, queueKey string, jobData []byte) error {
+	jobPipelineMu.Lock()
+	cmd := jobPipeliner.XAdd(ctx, &redis.XAddArgs{
+		Stream: queueKey,
+		Values: map[string]interface{}{"job": jobData},
+	})
+	flushed := jobPipelineFlushed
+	jobPipelineMu.Unlock()
+
+	select {
+	case <-flushed:
+		return cmd.Err()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// runJobPipelineFlusher flushes the pipelined job queue on every
+// jobPipePeriod tick, and once more on shutdown to drain whatever is still
+// buffered before gracefulShutdown closes the Redis client.
+func runJobPipelineFlusher() {
+	ticker := time.NewTicker(jobPipePeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			flushJobPipeline()
+
+		case <-jobPipelineStopChan:
+			flushJobPipeline()
+			close(jobPipelineStoppedChan)
+			return
+		}
+	}
+}
+
+// flushJobPipeline swaps out the shared pipeliner for a fresh one and Execs
+// the old one, with the existing retry/circuit-breaker wrappers now guarding
+// the flush instead of the per-job append. Every enqueue blocked on the
+// flushed channel it captured wakes up once this Exec returns and reads its
+// own command's result.
+func flushJobPipeline() {
+	jobPipelineMu.Lock()
+	if jobPipeliner.Len() == 0 {
+		jobPipelineMu.Unlock()
+		return
+	}
+	pipe := jobPipeliner
+	flushed := jobPipelineFlushed
+	batchSize := pipe.Len()
+	jobPipeliner = rdb.Pipeline()
+	jobPipelineFlushed = make(chan struct{})
+	jobPipelineMu.Unlock()
+
+	flushStart := time.Now()
+	err := jobFlushPolicy.Execute(ctx, func(ctx context.Context) error {
+		_, execErr := pipe.Exec(ctx)
+		return execErr
+	})
+	flushDuration := time.Since(flushStart)
+
+	metricsCollector.RecordRedisPipelineBatchSize(float64(batchSize))
+	metricsCollector.RecordRedisPipelineFlushDuration(flushDuration)
+
+	if err != nil {
+		metricsCollector.IncrementCircuitBreakerFailures("redis", "api")
+		logger.WithFields(map[string]interface{}{
+			"batch_size": batchSize,
+			"operation":  "redis_job_pipeline_flush",
+			"error":      err.Error(),
+		}).Warn("Pipelined job enqueue flush failed")
+	}
+
+	close(flushed)
+}
+
+// cancelJobHandler handles DELETE /jobs/{id}, publishing a cancel signal on
+// the job's Redis control channel so whichever worker is holding it can stop
+// the in-flight Stockfish computation
+func cancelJobHandler(w http.ResponseWriter, r *http.Request) {
+	correlationID, _ := correlation.FromContext(r.Context())
+	reqLogger := logger.WithCorrelationID(correlationID)
+
+	enableCORS(&w, r)
+	if r.Method != http.MethodDelete {
+		http.Error(w, "DELETE only", http.StatusMethodNotAllowed)
+		return
+	}
+
+	jobID := strings.TrimPrefix(r.URL.Path, "/jobs/")
+	if jobID == "" {
+		http.Error(w, "missing job id", http.StatusBadRequest)
+		return
+	}
+
+	if err := rdb.Publish(r.Context(), keys.ControlChannel(jobID), "cancel").Err(); err != nil {
+		reqLogger.WithField("job_id", jobID).Error("Failed to publish job cancellation", err)
+		http.Error(w, "failed to publish cancellation", http.StatusServiceUnavailable)
+		return
+	}
+
+	reqLogger.WithField("job_id", jobID).Info("Published job cancellation request")
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// cacheInvalidateHandler handles DELETE /cache?fen=...&elo=...&movetime_ms=...,
+// evicting the matching (FEN, Elo, MoveTimeMs) entry from both move cache
+// tiers so the next identical request re-runs the engine.
+func cacheInvalidateHandler(w http.ResponseWriter, r *http.Request) {
+	correlationID, _ := correlation.FromContext(r.Context())
+	reqLogger := logger.WithCorrelationID(correlationID)
+
+	enableCORS(&w, r)
+	if r.Method != http.MethodDelete {
+		http.Error(w, "DELETE only", http.StatusMethodNotAllowed)
+		return
+	}
+
+	fen := r.URL.Query().Get("fen")
+	if fen == "" {
+		http.Error(w, "missing fen", http.StatusBadRequest)
+		return
+	}
+	elo, _ := strconv.Atoi(r.URL.Query().Get("elo"))
+	if elo == 0 {
+		elo = 1600
+	}
+	moveTimeMs, _ := strconv.Atoi(r.URL.Query().Get("movetime_ms"))
+	if moveTimeMs <= 0 {
+		moveTimeMs = 1000
+	}
+
+	cacheKey := movecache.Key(fen, elo, moveTimeMs)
+	if err := moveCache.Invalidate(r.Context(), cacheKey); err != nil {
+		reqLogger.Error("Failed to invalidate move cache entry", err)
+		http.Error(w, "failed to invalidate cache entry", http.StatusServiceUnavailable)
+		return
+	}
+
+	reqLogger.WithField("cache_key", cacheKey).Info("Invalidated move cache entry")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PeriodicTemplateRequest is the request body for POST /periodic.
+type PeriodicTemplateRequest struct {
+	ID                string `json:"id,omitempty"`
+	Spec              string `json:"spec"`
+	FEN               string `json:"fen"`
+	Elo               int    `json:"elo"`
+	MoveTimeMs        int    `json:"movetime_ms"`
+	Paused            bool   `json:"paused,omitempty"`
+	MissedFiresPolicy string `json:"missed_fires_policy,omitempty"`
+}
+
+// periodicTemplatesHandler handles GET (list) and POST (create) on the
+// periodic job template collection.
+func periodicTemplatesHandler(w http.ResponseWriter, r *http.Request) {
+	correlationID, _ := correlation.FromContext(r.Context())
+	reqLogger := logger.WithCorrelationID(correlationID)
+
+	enableCORS(&w, r)
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodGet:
+		listPeriodicTemplates(w, r, reqLogger)
+	case http.MethodPost:
+		createPeriodicTemplate(w, r, reqLogger)
+	default:
+		http.Error(w, "GET or POST only", http.StatusMethodNotAllowed)
+	}
+}
+
+func listPeriodicTemplates(w http.ResponseWriter, r *http.Request, reqLogger logging.Logger) {
+	templates, err := periodicScheduler.ListTemplates(r.Context())
+	if err != nil {
+		reqLogger.Error("Failed to list periodic templates", err)
+		http.Error(w, "failed to list periodic templates", http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(templates)
+}
+
+func createPeriodicTemplate(w http.ResponseWriter, r *http.Request, reqLogger logging.Logger) {
+	var req PeriodicTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "bad json: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.FEN == "" || req.Spec == "" {
+		http.Error(w, "missing fen or spec", http.StatusBadRequest)
+		return
+	}
+	if req.ID == "" {
+		req.ID = fmt.Sprintf("periodic_%d", time.Now().UnixNano())
+	}
+	if req.Elo == 0 {
+		req.Elo = 1600
+	}
+	if req.MoveTimeMs <= 0 {
+		req.MoveTimeMs = 1000
+	}
+
+	tpl := &scheduler.Template{
+		ID:          req.ID,
+		Spec:        req.Spec,
+		FEN:         req.FEN,
+		Elo:         req.Elo,
+		MaxTimeMs:   req.MoveTimeMs,
+		Paused:      req.Paused,
+		MissedFires: scheduler.MissedFiresPolicy(req.MissedFiresPolicy),
+	}
+
+	if err := periodicScheduler.CreateTemplate(r.Context(), tpl); err != nil {
+		reqLogger.WithField("template_id", req.ID).Error("Failed to create periodic template", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	reqLogger.WithField("template_id", tpl.ID).Info("Created periodic job template")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(tpl)
+}
+
+// periodicTemplateHandler handles DELETE /periodic/{id}, POST
+// /periodic/{id}/pause, and POST /periodic/{id}/resume.
+func periodicTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	correlationID, _ := correlation.FromContext(r.Context())
+	reqLogger := logger.WithCorrelationID(correlationID)
+
+	enableCORS(&w, r)
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/periodic/")
+	id, action := path, ""
+	if idx := strings.LastIndex(path, "/"); idx != -1 {
+		id, action = path[:idx], path[idx+1:]
+	}
+	if id == "" {
+		http.Error(w, "missing template id", http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case action == "" && r.Method == http.MethodDelete:
+		if err := periodicScheduler.DeleteTemplate(r.Context(), id); err != nil {
+			reqLogger.WithField("template_id", id).Error("Failed to delete periodic template", err)
+			http.Error(w, "failed to delete template", http.StatusInternalServerError)
+			return
+		}
+		reqLogger.WithField("template_id", id).Info("Deleted periodic job template")
+		w.WriteHeader(http.StatusNoContent)
+
+	case action == "pause" && r.Method == http.MethodPost:
+		setPeriodicPaused(w, r, reqLogger, id, true)
+
+	case action == "resume" && r.Method == http.MethodPost:
+		setPeriodicPaused(w, r, reqLogger, id, false)
+
+	default:
+		http.Error(w, "unsupported method or action", http.StatusMethodNotAllowed)
+	}
+}
+
+func setPeriodicPaused(w http.ResponseWriter, r *http.Request, reqLogger logging.Logger, id string, paused bool) {
+	if err := periodicScheduler.SetPaused(r.Context(), id, paused); err != nil {
+		reqLogger.WithField("template_id", id).Error("Failed to update periodic template", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	reqLogger.WithFields(map[string]interface{}{
+		"template_id": id,
+		"paused":      paused,
+	}).Info("Updated periodic job template pause state")
+	w.WriteHeader(http.StatusNoContent)
 }
 
 // sendCircuitBreakerError sends HTTP 503 with retry-after header (requirement 3.7)
 func sendCircuitBreakerError(w http.ResponseWriter, correlationID string, reqLogger logging.Logger) {
 	reqLogger.Warn("Redis circuit breaker is open")
-	
+
 	cbMetrics := redisCircuitBreaker.Metrics()
-	
+
 	errorResp := ErrorResponse{
 		Error: ErrorDetail{
 			Code:              "SERVICE_UNAVAILABLE",
@@ -474,42 +1195,124 @@ func sendCircuitBreakerError(w http.ResponseWriter, correlationID string, reqLog
 			},
 		},
 	}
-	
+
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Retry-After", "30")
 	w.WriteHeader(http.StatusServiceUnavailable)
 	json.NewEncoder(w).Encode(errorResp)
 }
 
-func waitForResult(jobID string, timeout time.Duration, correlationID string, reqLogger logging.Logger) (*JobResult, error) {
-	deadline := time.Now().Add(timeout)
+// resultWaiters and resultWaitersMu implement a keywatcher modeled on
+// GitLab workhorse's: one dedicated PubSub subscription per process (see
+// startResultKeywatcher) fans each worker's completion notification out to
+// whichever goroutine is waiting on that job ID, instead of every request
+// spin-polling Redis itself.
+var (
+	resultWaiters   = make(map[string]chan *JobResult)
+	resultWaitersMu sync.Mutex
+)
 
-	for time.Now().Before(deadline) {
-		results, err := rdb.LRange(ctx, "stockfish:results", 0, -1).Result()
-		if err != nil {
-			time.Sleep(100 * time.Millisecond)
-			continue
-		}
+// startResultKeywatcher subscribes to keys.ResultsNotifyChannel for the
+// life of the process, delivering each completed job's result to its
+// registered waiter, if any is still listening by the time it arrives.
+func startResultKeywatcher() {
+	pubsub := rdb.Subscribe(ctx, keys.ResultsNotifyChannel)
+	ch := pubsub.Channel()
+	for msg := range ch {
+		deliverResult(msg.Payload)
+	}
+}
 
-		for _, resultStr := range results {
-			var result JobResult
-			if err := json.Unmarshal([]byte(resultStr), &result); err != nil {
-				continue
-			}
+// deliverResult looks up the waiter registered for jobID - the notification
+// payload - and, if one is still waiting, fetches and delivers its result.
+func deliverResult(jobID string) {
+	resultWaitersMu.Lock()
+	waiter, ok := resultWaiters[jobID]
+	if ok {
+		delete(resultWaiters, jobID)
+	}
+	resultWaitersMu.Unlock()
 
-			if result.JobID == jobID {
-				rdb.LRem(ctx, "stockfish:results", 1, resultStr)
+	if !ok {
+		return
+	}
 
-				if result.Error != "" {
-					return nil, fmt.Errorf("engine error: %s", result.Error)
-				}
-				return &result, nil
-			}
+	result, err := fetchResult(jobID)
+	if err != nil {
+		waiter <- nil
+		return
+	}
+	waiter <- result
+}
+
+// fetchResult GETs and unmarshals the serialized JobResult a worker SET at
+// keys.ResultKey(jobID).
+func fetchResult(jobID string) (*JobResult, error) {
+	data, err := rdb.Get(ctx, keys.ResultKey(jobID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	var result JobResult
+	if err := json.Unmarshal([]byte(data), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// registerResultWaiter registers jobID as awaiting a result and returns the
+// channel its delivery will arrive on (buffered by one so deliverResult
+// never blocks on a caller that has already timed out). Callers must
+// eventually call unregisterResultWaiter if they give up before a delivery
+// arrives.
+func registerResultWaiter(jobID string) chan *JobResult {
+	ch := make(chan *JobResult, 1)
+	resultWaitersMu.Lock()
+	resultWaiters[jobID] = ch
+	resultWaitersMu.Unlock()
+	return ch
+}
+
+// unregisterResultWaiter removes jobID's waiter registration. Safe to call
+// even if deliverResult already removed it first.
+func unregisterResultWaiter(jobID string) {
+	resultWaitersMu.Lock()
+	delete(resultWaiters, jobID)
+	resultWaitersMu.Unlock()
+}
+
+// waitForResult registers jobID with the keywatcher and blocks until its
+// result is delivered or timeout elapses. A fallback GET right after
+// registering covers the race where the worker published before this
+// waiter was registered - the same race the GitLab workhorse keywatcher
+// design handles by racing a direct read against the subscription.
+func waitForResult(jobID string, timeout time.Duration, correlationID string, reqLogger logging.Logger) (*JobResult, error) {
+	waiter := registerResultWaiter(jobID)
+
+	if result, err := fetchResult(jobID); err == nil {
+		unregisterResultWaiter(jobID)
+		if result.Error != "" {
+			return nil, fmt.Errorf("engine error: %s", result.Error)
 		}
-		time.Sleep(100 * time.Millisecond)
+		return result, nil
 	}
 
-	return nil, fmt.Errorf("timeout waiting for job result")
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case result := <-waiter:
+		if result == nil {
+			return nil, fmt.Errorf("failed to fetch job result")
+		}
+		if result.Error != "" {
+			return nil, fmt.Errorf("engine error: %s", result.Error)
+		}
+		return result, nil
+
+	case <-timer.C:
+		unregisterResultWaiter(jobID)
+		return nil, fmt.Errorf("timeout waiting for job result")
+	}
 }
 
 // gracefulShutdown implements graceful shutdown (requirement 6.6)
@@ -517,20 +1320,38 @@ func gracefulShutdown(server *http.Server) {
 	// Wait for interrupt signal
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
-	
+
 	sig := <-sigChan
 	logger.WithField("signal", sig.String()).Info("Received shutdown signal")
-	
+
 	// Create shutdown context with 30s timeout (requirement 6.6)
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
-	
+
 	// Stop accepting new requests and wait for in-flight requests
 	logger.Info("Shutting down server gracefully")
 	if err := server.Shutdown(shutdownCtx); err != nil {
 		logger.Error("Server shutdown error", err)
 	}
-	
+
+	// Stop the periodic job scheduler's tick loop
+	if periodicScheduler != nil {
+		periodicScheduler.Stop()
+	}
+
+	// Flush any per-tenant counts accumulated since the last tick
+	if tenantAggregator != nil {
+		tenantAggregator.Stop()
+	}
+
+	// Drain the pipelined job enqueue queue with one final flush before the
+	// Redis client closes underneath it
+	if jobPipeliningEnabled {
+		close(jobPipelineStopChan)
+		<-jobPipelineStoppedChan
+		logger.Info("Pipelined job enqueue drained")
+	}
+
 	// Close Redis connection cleanly (requirement 6.6)
 	if rdb != nil {
 		if err := rdb.Close(); err != nil {
@@ -539,7 +1360,7 @@ func gracefulShutdown(server *http.Server) {
 			logger.Info("Redis connection closed")
 		}
 	}
-	
+
 	logger.Info("Server stopped")
 }
 
@@ -557,6 +1378,18 @@ func getenv(k, def string) string {
 	return def
 }
 
+// tenantFromRequest returns r's tenantHeader value, resolved through
+// tenantAggregator so the label handed to the *Vec metrics is already
+// folded into tenantstats.OverflowTenant once MaxTenantCardinality distinct
+// tenants have been seen.
+func tenantFromRequest(r *http.Request) string {
+	tenant := r.Header.Get(tenantHeader)
+	if tenantAggregator == nil {
+		return tenant
+	}
+	return tenantAggregator.Resolve(tenant)
+}
+
 func truncateString(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
@@ -564,57 +1397,75 @@ func truncateString(s string, maxLen int) string {
 	return s[:maxLen] + "..."
 }
 
+// trackAuditLogBufferSize periodically reports auditWriter's backlog
+// (AuditLogWriter.GetBufferSize()) so a writer falling behind shows up
+// before its channel fills and starts blocking every logging call site.
+func trackAuditLogBufferSize() {
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		<-ticker.C
+		metricsCollector.SetAuditLogBufferSize("api", "stdout", float64(auditWriter.GetBufferSize()))
+	}
+}
+
 // trackQueueDepthVariance periodically tracks queue depth and calculates variance
 // Requirement 5.7: Calculate standard deviation of queue depth over time windows
 func trackQueueDepthVariance() {
 	ticker := time.NewTicker(15 * time.Second)
 	defer ticker.Stop()
-	
+
 	for {
 		<-ticker.C
 		updateQueueDepthVariance()
 	}
 }
 
-// updateQueueDepthVariance updates queue depth history and calculates variance
+// updateQueueDepthVariance updates queue depth history and calculates
+// variance, sampling XLEN (queue depth) and the stream's pending count
+// separately since a growing pending count with a flat XLEN points at stuck
+// consumers rather than an under-provisioned queue.
 func updateQueueDepthVariance() {
-	// Get current queue depth
-	queueDepth, err := rdb.LLen(ctx, "stockfish:jobs").Result()
-	if err != nil {
-		return
-	}
-	
+	queueDepth := totalQueueDepth(ctx)
+	pendingCount := totalPendingCount(ctx)
+	consumerLag := oldestPendingLag(ctx)
+
+	metricsCollector.SetStreamPending(float64(pendingCount))
+	metricsCollector.SetStreamConsumerLag(consumerLag)
+
 	// Add to history
 	queueDepthMu.Lock()
 	snapshot := queueDepthSnapshot{
 		timestamp: time.Now(),
 		depth:     queueDepth,
+		pending:   pendingCount,
 	}
 	queueDepthHistory = append(queueDepthHistory, snapshot)
-	
+
 	// Keep only last hour of data (240 samples at 15s intervals)
 	if len(queueDepthHistory) > 240 {
 		queueDepthHistory = queueDepthHistory[len(queueDepthHistory)-240:]
 	}
 	queueDepthMu.Unlock()
-	
+
 	// Calculate variance over different time windows
-	calculateAndExposeVariance(5 * time.Minute)   // 5-minute window
-	calculateAndExposeVariance(15 * time.Minute)  // 15-minute window
-	calculateAndExposeVariance(60 * time.Minute)  // 1-hour window
+	calculateAndExposeVariance(5 * time.Minute)  // 5-minute window
+	calculateAndExposeVariance(15 * time.Minute) // 15-minute window
+	calculateAndExposeVariance(60 * time.Minute) // 1-hour window
 }
 
 // calculateAndExposeVariance calculates standard deviation for a time window
 func calculateAndExposeVariance(window time.Duration) {
 	queueDepthMu.RLock()
 	defer queueDepthMu.RUnlock()
-	
+
 	if len(queueDepthHistory) == 0 {
 		return
 	}
-	
+
 	cutoff := time.Now().Add(-window)
-	
+
 	// Collect samples within window
 	var samples []float64
 	for _, snapshot := range queueDepthHistory {
@@ -622,18 +1473,18 @@ func calculateAndExposeVariance(window time.Duration) {
 			samples = append(samples, float64(snapshot.depth))
 		}
 	}
-	
+
 	if len(samples) < 2 {
 		return
 	}
-	
+
 	// Calculate mean
 	var sum float64
 	for _, value := range samples {
 		sum += value
 	}
 	mean := sum / float64(len(samples))
-	
+
 	// Calculate variance
 	var varianceSum float64
 	for _, value := range samples {
@@ -641,10 +1492,10 @@ func calculateAndExposeVariance(window time.Duration) {
 		varianceSum += diff * diff
 	}
 	variance := varianceSum / float64(len(samples))
-	
+
 	// Calculate standard deviation
 	stdDev := math.Sqrt(variance)
-	
+
 	// Expose metric (Requirement 5.7)
 	// We use the standard deviation as the variance metric
 	metricsCollector.SetQueueDepthVariance(stdDev)
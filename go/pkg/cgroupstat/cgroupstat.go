@@ -0,0 +1,173 @@
+// Package cgroupstat reads Linux cgroup CPU accounting and PSI pressure
+// files, supporting cgroup v2's unified hierarchy with a v1 fallback, so
+// CPU-seconds tracking can see container-level throttling that procfs
+// doesn't expose.
+package cgroupstat
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	cgroupV2CPUStat     = "/sys/fs/cgroup/cpu.stat"
+	cgroupV2CPUPressure = "/sys/fs/cgroup/cpu.pressure"
+	cgroupV1CPUAcctUsage = "/sys/fs/cgroup/cpuacct/cpuacct.usage"
+	cgroupV1CPUStat      = "/sys/fs/cgroup/cpu/cpu.stat"
+)
+
+// Stat is this process's cgroup CPU accounting: usage plus the throttling
+// cgroup v2's cpu.stat (or the v1 cpuacct/cpu.stat split) reports.
+type Stat struct {
+	UsageUsec     uint64
+	UserUsec      uint64
+	SystemUsec    uint64
+	NrThrottled   uint64
+	ThrottledUsec uint64
+}
+
+// PressureLine is one "some"/"full" line of a PSI pressure file.
+type PressureLine struct {
+	Avg10  float64
+	Avg60  float64
+	Avg300 float64
+	Total  uint64
+}
+
+// Pressure is the parsed contents of a cgroup cpu.pressure file.
+type Pressure struct {
+	Some PressureLine
+	Full PressureLine
+}
+
+// ReadStat reads this process's cgroup CPU accounting, preferring cgroup
+// v2's unified cpu.stat and falling back to the v1 cpuacct.usage/cpu.stat
+// split if the v2 file doesn't exist.
+func ReadStat() (*Stat, error) {
+	if stat, err := readV2Stat(); err == nil {
+		return stat, nil
+	}
+	return readV1Stat()
+}
+
+func readV2Stat() (*Stat, error) {
+	fields, err := readKeyedFile(cgroupV2CPUStat)
+	if err != nil {
+		return nil, fmt.Errorf("cgroupstat: read %s: %w", cgroupV2CPUStat, err)
+	}
+	if _, ok := fields["usage_usec"]; !ok {
+		return nil, fmt.Errorf("cgroupstat: %s has no usage_usec", cgroupV2CPUStat)
+	}
+	return &Stat{
+		UsageUsec:     fields["usage_usec"],
+		UserUsec:      fields["user_usec"],
+		SystemUsec:    fields["system_usec"],
+		NrThrottled:   fields["nr_throttled"],
+		ThrottledUsec: fields["throttled_usec"],
+	}, nil
+}
+
+func readV1Stat() (*Stat, error) {
+	usageData, err := os.ReadFile(cgroupV1CPUAcctUsage)
+	if err != nil {
+		return nil, fmt.Errorf("cgroupstat: read %s: %w", cgroupV1CPUAcctUsage, err)
+	}
+	usageNanos, err := strconv.ParseUint(strings.TrimSpace(string(usageData)), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("cgroupstat: parse %s: %w", cgroupV1CPUAcctUsage, err)
+	}
+
+	stat := &Stat{UsageUsec: usageNanos / 1000}
+
+	// The v1 "cpu" controller's cpu.stat reports throttling in nanoseconds
+	// as nr_periods/nr_throttled/throttled_time; it doesn't break out
+	// user/system the way v2's cpu.stat does, so those stay zero.
+	if fields, err := readKeyedFile(cgroupV1CPUStat); err == nil {
+		stat.NrThrottled = fields["nr_throttled"]
+		stat.ThrottledUsec = fields["throttled_time"] / 1000
+	}
+
+	return stat, nil
+}
+
+// readKeyedFile parses a "key value" per line file, the format cgroup's
+// cpu.stat uses in both v1 and v2, keeping only integer values.
+func readKeyedFile(path string) (map[string]uint64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	fields := make(map[string]uint64)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		parts := strings.Fields(scanner.Text())
+		if len(parts) != 2 {
+			continue
+		}
+		value, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		fields[parts[0]] = value
+	}
+	return fields, scanner.Err()
+}
+
+// ReadPressure reads this process's cgroup CPU PSI pressure file. It is
+// cgroup-v2-only; a v1-only host returns an error.
+func ReadPressure() (*Pressure, error) {
+	f, err := os.Open(cgroupV2CPUPressure)
+	if err != nil {
+		return nil, fmt.Errorf("cgroupstat: read %s: %w", cgroupV2CPUPressure, err)
+	}
+	defer f.Close()
+
+	var pressure Pressure
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		parsed := parsePressureLine(line)
+		switch {
+		case strings.HasPrefix(line, "some"):
+			pressure.Some = parsed
+		case strings.HasPrefix(line, "full"):
+			pressure.Full = parsed
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("cgroupstat: read %s: %w", cgroupV2CPUPressure, err)
+	}
+	return &pressure, nil
+}
+
+// parsePressureLine parses one line of a PSI pressure file, e.g.
+// "some avg10=0.00 avg60=0.00 avg300=0.00 total=0".
+func parsePressureLine(line string) PressureLine {
+	var result PressureLine
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return result
+	}
+	for _, field := range fields[1:] {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "avg10":
+			result.Avg10, _ = strconv.ParseFloat(kv[1], 64)
+		case "avg60":
+			result.Avg60, _ = strconv.ParseFloat(kv[1], 64)
+		case "avg300":
+			result.Avg300, _ = strconv.ParseFloat(kv[1], 64)
+		case "total":
+			result.Total, _ = strconv.ParseUint(kv[1], 10, 64)
+		}
+	}
+	return result
+}
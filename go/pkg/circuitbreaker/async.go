@@ -0,0 +1,117 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrQueueFull is returned by AsyncCircuitBreaker.SubmitAsync when
+// MaxConcurrent submissions are already in flight.
+var ErrQueueFull = errors.New("circuit breaker: async queue is full")
+
+// AsyncCircuitBreaker protects a fire-and-forget call path (e.g. worker ->
+// Redis result stream) with its own bounded worker pool, so a caller handing
+// off work never blocks on it: SubmitAsync starts fn in a new goroutine and
+// returns immediately, refusing new work - with ErrCircuitOpen while tripped,
+// ErrQueueFull once MaxConcurrent submissions are already in flight - instead
+// of letting the caller's goroutine count grow unbounded while the downstream
+// is slow or down. Modeled on the async-set circuit breaker Thanos added to
+// its cache client.
+type AsyncCircuitBreaker struct {
+	cb         *CircuitBreaker
+	metricsCol MetricsCollector
+	service    string
+	component  string
+
+	sem chan struct{} // one slot per in-flight SubmitAsync call, sized MaxConcurrent
+
+	mu    sync.Mutex
+	depth int
+}
+
+// NewAsyncCircuitBreaker creates an AsyncCircuitBreaker backed by a
+// CircuitBreaker built from config, with at most maxConcurrent submissions
+// in flight at once. service/component label the queue-depth and
+// dropped-submission metrics reported through metricsCol, which may be nil.
+func NewAsyncCircuitBreaker(name string, config Config, maxConcurrent int, metricsCol MetricsCollector, service, component string) *AsyncCircuitBreaker {
+	return &AsyncCircuitBreaker{
+		cb:         New(name, config),
+		metricsCol: metricsCol,
+		service:    service,
+		component:  component,
+		sem:        make(chan struct{}, maxConcurrent),
+	}
+}
+
+// SubmitAsync runs fn in a new goroutine guarded by acb's circuit breaker and
+// bounded worker pool. It returns ErrCircuitOpen immediately if the breaker
+// is open, or ErrQueueFull if MaxConcurrent submissions are already in
+// flight - in both cases dropping fn (incrementing the dropped-submission
+// metric) rather than enqueuing it. A queue-full rejection also counts
+// toward the breaker's trip threshold like a failed execution, so a
+// downstream slow enough to keep saturating MaxConcurrent trips the breaker
+// even if every individual call would eventually have succeeded.
+func (acb *AsyncCircuitBreaker) SubmitAsync(fn func() error) error {
+	if acb.cb.IsOpen() {
+		acb.recordDropped()
+		return ErrCircuitOpen
+	}
+
+	select {
+	case acb.sem <- struct{}{}:
+	default:
+		acb.recordDropped()
+		acb.cb.Call(func() error { return ErrQueueFull })
+		return ErrQueueFull
+	}
+
+	acb.reportQueueDepth(acb.changeDepth(1))
+
+	go func() {
+		defer acb.reportQueueDepth(acb.changeDepth(-1))
+		defer func() { <-acb.sem }()
+		_ = acb.cb.Call(fn)
+	}()
+
+	return nil
+}
+
+// changeDepth adjusts the in-flight submission count by delta and returns
+// the new value.
+func (acb *AsyncCircuitBreaker) changeDepth(delta int) int {
+	acb.mu.Lock()
+	defer acb.mu.Unlock()
+	acb.depth += delta
+	return acb.depth
+}
+
+func (acb *AsyncCircuitBreaker) recordDropped() {
+	if acb.metricsCol != nil {
+		acb.metricsCol.IncrementCircuitBreakerAsyncDropped(acb.service, acb.component)
+	}
+}
+
+func (acb *AsyncCircuitBreaker) reportQueueDepth(depth int) {
+	if acb.metricsCol != nil {
+		acb.metricsCol.SetCircuitBreakerAsyncQueueDepth(acb.service, acb.component, float64(depth))
+	}
+}
+
+// RedisResultAsyncCircuitBreakerConfig returns the configuration for the
+// worker's fire-and-forget Redis result-publishing path: the same 3
+// consecutive failures / 30s timeout as RedisCircuitBreakerConfig, plus the
+// percentage trip policy so a burst of scattered queue-full/publish failures
+// opens the circuit as fast as 3 in a row.
+func RedisResultAsyncCircuitBreakerConfig() Config {
+	return Config{
+		FailureThreshold:           3,
+		Timeout:                    30 * time.Second,
+		SuccessThreshold:           1,
+		MaxRequests:                1,
+		FailureThresholdPercentage: 50,
+		MinRequestsThreshold:       5,
+		WindowDuration:             30 * time.Second,
+		InitialDelay:               5 * time.Second,
+	}
+}
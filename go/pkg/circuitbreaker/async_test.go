@@ -0,0 +1,139 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeAsyncMetrics is a minimal MetricsCollector recording calls instead of
+// exporting anything, so tests can assert on what AsyncCircuitBreaker
+// reported without a real Prometheus registry.
+type fakeAsyncMetrics struct {
+	mu      sync.Mutex
+	dropped int
+	depths  []float64
+}
+
+func (f *fakeAsyncMetrics) SetCircuitBreakerState(service, component string, state float64) {}
+func (f *fakeAsyncMetrics) IncrementCircuitBreakerFailures(service, component string)       {}
+
+func (f *fakeAsyncMetrics) SetCircuitBreakerAsyncQueueDepth(service, component string, depth float64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.depths = append(f.depths, depth)
+}
+
+func (f *fakeAsyncMetrics) IncrementCircuitBreakerAsyncDropped(service, component string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.dropped++
+}
+
+func (f *fakeAsyncMetrics) droppedCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.dropped
+}
+
+func TestSubmitAsyncRunsAcceptedWork(t *testing.T) {
+	metrics := &fakeAsyncMetrics{}
+	acb := NewAsyncCircuitBreaker("test", Config{FailureThreshold: 5, Timeout: time.Minute, MaxRequests: 1}, 4, metrics, "redis", "test")
+
+	done := make(chan struct{})
+	if err := acb.SubmitAsync(func() error {
+		close(done)
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("SubmitAsync never ran fn")
+	}
+}
+
+func TestSubmitAsyncDropsWhenCircuitOpen(t *testing.T) {
+	metrics := &fakeAsyncMetrics{}
+	acb := NewAsyncCircuitBreaker("test", Config{FailureThreshold: 1, Timeout: time.Minute, MaxRequests: 1}, 4, metrics, "redis", "test")
+
+	// Trip the breaker with one failure submitted through the same
+	// underlying CircuitBreaker SubmitAsync wraps.
+	done := make(chan struct{})
+	acb.SubmitAsync(func() error {
+		defer close(done)
+		return errors.New("boom")
+	})
+	<-done
+
+	// done closes inside fn, before breaker.Execute has recorded the
+	// failure, so give cb's internal state a moment to settle.
+	deadline := time.Now().Add(time.Second)
+	for !acb.cb.IsOpen() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if !acb.cb.IsOpen() {
+		t.Fatal("expected underlying breaker to be open after a failure past FailureThreshold")
+	}
+
+	if err := acb.SubmitAsync(func() error { t.Fatal("fn must not run while circuit is open"); return nil }); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got %v, want ErrCircuitOpen", err)
+	}
+	if got := metrics.droppedCount(); got != 1 {
+		t.Fatalf("dropped count = %d, want 1", got)
+	}
+}
+
+func TestSubmitAsyncDropsWhenQueueFull(t *testing.T) {
+	metrics := &fakeAsyncMetrics{}
+	acb := NewAsyncCircuitBreaker("test", Config{FailureThreshold: 100, Timeout: time.Minute, MaxRequests: 1}, 1, metrics, "redis", "test")
+
+	block := make(chan struct{})
+	started := make(chan struct{})
+	if err := acb.SubmitAsync(func() error {
+		close(started)
+		<-block
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error on first submission: %v", err)
+	}
+	<-started
+
+	// MaxConcurrent is 1 and the first submission is still in flight, so
+	// this one must be rejected rather than queued.
+	if err := acb.SubmitAsync(func() error { t.Fatal("fn must not run when the queue is full"); return nil }); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("got %v, want ErrQueueFull", err)
+	}
+	if got := metrics.droppedCount(); got != 1 {
+		t.Fatalf("dropped count = %d, want 1", got)
+	}
+
+	close(block)
+}
+
+func TestSubmitAsyncQueueFullCountsTowardTrip(t *testing.T) {
+	metrics := &fakeAsyncMetrics{}
+	acb := NewAsyncCircuitBreaker("test", Config{FailureThreshold: 1, Timeout: time.Minute, MaxRequests: 1}, 1, metrics, "redis", "test")
+
+	block := make(chan struct{})
+	started := make(chan struct{})
+	acb.SubmitAsync(func() error {
+		close(started)
+		<-block
+		return nil
+	})
+	<-started
+
+	// A queue-full rejection should register as a failed execution on the
+	// underlying breaker, not just on the dropped-submission metric.
+	acb.SubmitAsync(func() error { return nil })
+
+	if !acb.cb.IsOpen() {
+		t.Fatal("expected a queue-full rejection to count toward FailureThreshold and trip the breaker")
+	}
+
+	close(block)
+}
@@ -32,16 +32,34 @@ func (s State) String() string {
 
 // Config holds circuit breaker configuration
 type Config struct {
-	FailureThreshold uint32        // Number of failures before opening
+	FailureThreshold uint32        // Number of consecutive failures before opening
 	SuccessThreshold uint32        // Number of successes to close from half-open
 	Timeout          time.Duration // Time to wait before transitioning to half-open
 	MaxRequests      uint32        // Max requests allowed in half-open state
+
+	// FailureThresholdPercentage, MinRequestsThreshold and WindowDuration add a
+	// percentage-based trip policy on top of FailureThreshold's consecutive-failure
+	// one: once at least MinRequestsThreshold requests have been observed inside the
+	// rolling WindowDuration, the breaker also opens if TotalFailures/Requests*100
+	// reaches FailureThresholdPercentage. Leave FailureThresholdPercentage or
+	// MinRequestsThreshold at zero to disable the ratio check and trip on
+	// FailureThreshold consecutive failures alone.
+	FailureThresholdPercentage uint
+	MinRequestsThreshold       uint32
+	WindowDuration             time.Duration
+
+	// InitialDelay is a warmup grace period, starting when New (or
+	// NewStockfishCircuitBreaker/NewRedisCircuitBreaker) is called, during which
+	// failures and successes are still recorded but ReadyToTrip always returns
+	// false. WarmingUp reports whether a breaker is still inside this window.
+	InitialDelay time.Duration
 }
 
 // CircuitBreaker wraps sony/gobreaker with custom metrics integration
 type CircuitBreaker struct {
 	breaker       *gobreaker.CircuitBreaker
 	config        Config
+	createdAt     time.Time
 	onStateChange func(from, to State)
 }
 
@@ -56,39 +74,87 @@ type Metrics struct {
 
 // New creates a new circuit breaker with the given configuration
 func New(name string, config Config) *CircuitBreaker {
+	createdAt := time.Now()
 	cb := &CircuitBreaker{
-		config: config,
+		config:    config,
+		createdAt: createdAt,
 	}
-	
+
 	settings := gobreaker.Settings{
 		Name:        name,
 		MaxRequests: config.MaxRequests,
-		Interval:    0, // No automatic reset
+		Interval:    config.WindowDuration, // rolling window for the failure-ratio policy; 0 means "never reset"
 		Timeout:     config.Timeout,
-		ReadyToTrip: func(counts gobreaker.Counts) bool {
-			// Open circuit after FailureThreshold consecutive failures
-			return counts.ConsecutiveFailures >= config.FailureThreshold
-		},
+		ReadyToTrip: readyToTrip(config, createdAt),
 		OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {
 			if cb.onStateChange != nil {
 				cb.onStateChange(convertState(from), convertState(to))
 			}
 		},
 	}
-	
+
 	cb.breaker = gobreaker.NewCircuitBreaker(settings)
-	
+
 	return cb
 }
 
-// Call executes the given function with circuit breaker protection
+// readyToTrip builds the ReadyToTrip predicate shared by New, NewStockfishCircuitBreaker
+// and NewRedisCircuitBreaker: trip on ConsecutiveFailures >= FailureThreshold (the
+// original policy), or, once MinRequestsThreshold requests have been observed inside the
+// current window, on a failure ratio >= FailureThresholdPercentage - but never before
+// InitialDelay has elapsed since createdAt, so a freshly started breaker can't trip on the
+// handful of requests seen while the process is still warming up.
+func readyToTrip(config Config, createdAt time.Time) func(gobreaker.Counts) bool {
+	return func(counts gobreaker.Counts) bool {
+		if warmingUp(config, createdAt) {
+			return false
+		}
+		if config.FailureThreshold > 0 && counts.ConsecutiveFailures >= config.FailureThreshold {
+			return true
+		}
+		if config.FailureThresholdPercentage > 0 && config.MinRequestsThreshold > 0 &&
+			counts.Requests >= config.MinRequestsThreshold {
+			failureRatio := float64(counts.TotalFailures) / float64(counts.Requests) * 100
+			if failureRatio >= float64(config.FailureThresholdPercentage) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// warmingUp reports whether createdAt is still inside config.InitialDelay.
+func warmingUp(config Config, createdAt time.Time) bool {
+	return config.InitialDelay > 0 && time.Since(createdAt) < config.InitialDelay
+}
+
+// Call executes the given function with circuit breaker protection. It is a
+// thin wrapper over the generic Execute for callers that don't need a typed
+// result.
 func (cb *CircuitBreaker) Call(fn func() error) error {
-	_, err := cb.breaker.Execute(func() (interface{}, error) {
-		return nil, fn()
+	_, err := Execute(cb, func() (struct{}, error) {
+		return struct{}{}, fn()
 	})
 	return err
 }
 
+// Execute runs fn with cb's circuit breaker protection and returns fn's typed
+// result directly, instead of callers having to close over a variable to
+// smuggle a result out of Call's func() error (which also hides the zero
+// value on failure). Go methods can't declare their own type parameters, so
+// this is a free function rather than a generic CircuitBreaker[T] type or a
+// CircuitBreaker.Execute[T] method.
+func Execute[T any](cb *CircuitBreaker, fn func() (T, error)) (T, error) {
+	result, err := cb.breaker.Execute(func() (interface{}, error) {
+		return fn()
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return result.(T), nil
+}
+
 // State returns the current circuit breaker state
 func (cb *CircuitBreaker) State() State {
 	return convertState(cb.breaker.State())
@@ -140,17 +206,37 @@ func (cb *CircuitBreaker) IsClosed() bool {
 	return cb.State() == StateClosed
 }
 
+// WarmingUp returns true while cb is still inside its Config.InitialDelay grace
+// period, during which ReadyToTrip always returns false regardless of
+// FailureThreshold or FailureThresholdPercentage - so dashboards can distinguish
+// "not yet armed" from "closed".
+func (cb *CircuitBreaker) WarmingUp() bool {
+	return warmingUp(cb.config, cb.createdAt)
+}
+
 // ErrCircuitOpen is returned when the circuit breaker is open
 var ErrCircuitOpen = fmt.Errorf("circuit breaker is open")
 
+// stateMetricWarmingUp is the SetCircuitBreakerState label value
+// NewStockfishCircuitBreaker/NewRedisCircuitBreaker report while a breaker is
+// warming up, distinct from the 0/1/2 closed/half-open/open values convertState's
+// callers already use, so dashboards can tell "not yet armed" from "closed".
+const stateMetricWarmingUp float64 = 3
+
 // StockfishCircuitBreakerConfig returns the configuration for Worker → Stockfish circuit breaker
-// Requirements 3.1-3.5: 5 failures in 60s threshold, 30s timeout
+// Requirements 3.1-3.5: 5 failures in 60s threshold, 30s timeout. Also trips if at least
+// 5 requests land inside the 60s window and >=50% of them fail, so a burst of scattered
+// (non-consecutive) failures opens the circuit just as fast as 5 in a row.
 func StockfishCircuitBreakerConfig() Config {
 	return Config{
-		FailureThreshold: 5,              // Open after 5 failures
-		Timeout:          30 * time.Second, // Wait 30s before half-open
-		SuccessThreshold: 1,              // Close after 1 success in half-open
-		MaxRequests:      1,              // Allow 1 test request in half-open
+		FailureThreshold:           5,                // Open after 5 consecutive failures
+		Timeout:                    30 * time.Second, // Wait 30s before half-open
+		SuccessThreshold:           1,                // Close after 1 success in half-open
+		MaxRequests:                1,                // Allow 1 test request in half-open
+		FailureThresholdPercentage: 50,               // ...or >=50% failures within the window
+		MinRequestsThreshold:       5,                // ...once at least 5 requests are observed
+		WindowDuration:             60 * time.Second, // 60s rolling window for the ratio check
+		InitialDelay:               10 * time.Second, // don't trip on the first 10s of traffic
 	}
 }
 
@@ -158,10 +244,11 @@ func StockfishCircuitBreakerConfig() Config {
 // Requirements 3.6-3.7: 3 failures in 30s threshold, 30s timeout
 func RedisCircuitBreakerConfig() Config {
 	return Config{
-		FailureThreshold: 3,              // Open after 3 failures
+		FailureThreshold: 3,                // Open after 3 consecutive failures
 		Timeout:          30 * time.Second, // Wait 30s before half-open
-		SuccessThreshold: 1,              // Close after 1 success in half-open
-		MaxRequests:      1,              // Allow 1 test request in half-open
+		SuccessThreshold: 1,                // Close after 1 success in half-open
+		MaxRequests:      1,                // Allow 1 test request in half-open
+		InitialDelay:     5 * time.Second,  // don't trip on the first 5s of traffic
 	}
 }
 
@@ -169,6 +256,12 @@ func RedisCircuitBreakerConfig() Config {
 type MetricsCollector interface {
 	SetCircuitBreakerState(service, component string, state float64)
 	IncrementCircuitBreakerFailures(service, component string)
+
+	// SetCircuitBreakerAsyncQueueDepth and IncrementCircuitBreakerAsyncDropped
+	// back AsyncCircuitBreaker's in-flight queue-depth gauge and
+	// dropped-submission counter.
+	SetCircuitBreakerAsyncQueueDepth(service, component string, depth float64)
+	IncrementCircuitBreakerAsyncDropped(service, component string)
 }
 
 // NewStockfishCircuitBreaker creates a circuit breaker for Stockfish connections with metrics
@@ -176,18 +269,14 @@ type MetricsCollector interface {
 // Requirements 3.1-3.5: 5 failures in 60s threshold, 30s timeout
 func NewStockfishCircuitBreaker(metricsCol MetricsCollector) *gobreaker.CircuitBreaker {
 	config := StockfishCircuitBreakerConfig()
-	
+	createdAt := time.Now()
+
 	settings := gobreaker.Settings{
 		Name:        "stockfish",
 		MaxRequests: config.MaxRequests,
-		Interval:    60 * time.Second, // 60s window for failure counting (Requirement 3.1)
-		Timeout:     config.Timeout,   // 30s timeout before half-open (Requirement 3.1)
-		ReadyToTrip: func(counts gobreaker.Counts) bool {
-			// Open after 5 failures within the 60s interval (Requirement 3.1)
-			failureRatio := float64(counts.TotalFailures) / float64(counts.Requests)
-			return counts.ConsecutiveFailures >= config.FailureThreshold || 
-			       (counts.Requests >= config.FailureThreshold && failureRatio >= 0.5)
-		},
+		Interval:    config.WindowDuration, // rolling window for the failure-ratio policy (Requirement 3.1)
+		Timeout:     config.Timeout,        // 30s timeout before half-open (Requirement 3.1)
+		ReadyToTrip: readyToTrip(config, createdAt),
 		OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {
 			// Update metrics on state change (Requirement 3.8)
 			var stateValue float64
@@ -199,33 +288,51 @@ func NewStockfishCircuitBreaker(metricsCol MetricsCollector) *gobreaker.CircuitB
 			case gobreaker.StateOpen:
 				stateValue = 2
 			}
-			
+
 			if metricsCol != nil {
 				metricsCol.SetCircuitBreakerState("stockfish", "worker", stateValue)
 			}
-			
+
 			// Increment failure count when opening (Requirement 3.8)
 			if to == gobreaker.StateOpen && metricsCol != nil {
 				metricsCol.IncrementCircuitBreakerFailures("stockfish", "worker")
 			}
 		},
 	}
-	
+
+	if metricsCol != nil {
+		reportWarmingUp(metricsCol, "stockfish", "worker", config, createdAt)
+	}
+
 	return gobreaker.NewCircuitBreaker(settings)
 }
 
+// reportWarmingUp sets service/component's state metric to stateMetricWarmingUp for
+// config.InitialDelay, then back to StateClosed once the grace period elapses - the raw
+// *gobreaker.CircuitBreaker NewStockfishCircuitBreaker/NewRedisCircuitBreaker return has no
+// WarmingUp accessor of its own to poll, so the warmup window is reported up front instead.
+func reportWarmingUp(metricsCol MetricsCollector, service, component string, config Config, createdAt time.Time) {
+	if config.InitialDelay <= 0 {
+		metricsCol.SetCircuitBreakerState(service, component, 0)
+		return
+	}
+	metricsCol.SetCircuitBreakerState(service, component, stateMetricWarmingUp)
+	time.AfterFunc(config.InitialDelay, func() {
+		metricsCol.SetCircuitBreakerState(service, component, 0)
+	})
+}
+
 // NewRedisCircuitBreaker creates a circuit breaker for Redis connections with metrics
 func NewRedisCircuitBreaker(metricsCol MetricsCollector) *gobreaker.CircuitBreaker {
 	config := RedisCircuitBreakerConfig()
-	
+	createdAt := time.Now()
+
 	settings := gobreaker.Settings{
 		Name:        "redis",
 		MaxRequests: config.MaxRequests,
-		Interval:    0,
+		Interval:    config.WindowDuration,
 		Timeout:     config.Timeout,
-		ReadyToTrip: func(counts gobreaker.Counts) bool {
-			return counts.ConsecutiveFailures >= config.FailureThreshold
-		},
+		ReadyToTrip: readyToTrip(config, createdAt),
 		OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {
 			var stateValue float64
 			switch to {
@@ -236,16 +343,20 @@ func NewRedisCircuitBreaker(metricsCol MetricsCollector) *gobreaker.CircuitBreak
 			case gobreaker.StateOpen:
 				stateValue = 2
 			}
-			
+
 			if metricsCol != nil {
 				metricsCol.SetCircuitBreakerState("redis", "worker", stateValue)
 			}
-			
+
 			if to == gobreaker.StateOpen && metricsCol != nil {
 				metricsCol.IncrementCircuitBreakerFailures("redis", "worker")
 			}
 		},
 	}
-	
+
+	if metricsCol != nil {
+		reportWarmingUp(metricsCol, "redis", "worker", config, createdAt)
+	}
+
 	return gobreaker.NewCircuitBreaker(settings)
 }
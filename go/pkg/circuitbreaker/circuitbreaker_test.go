@@ -0,0 +1,117 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sony/gobreaker"
+)
+
+func TestCallOpensOnConsecutiveFailures(t *testing.T) {
+	cb := New("test", Config{
+		FailureThreshold: 3,
+		Timeout:          time.Minute,
+		MaxRequests:      1,
+	})
+
+	failing := errors.New("boom")
+	for i := 0; i < 3; i++ {
+		if err := cb.Call(func() error { return failing }); !errors.Is(err, failing) {
+			t.Fatalf("attempt %d: got %v, want %v", i, err, failing)
+		}
+	}
+
+	if !cb.IsOpen() {
+		t.Fatal("expected breaker to be open after FailureThreshold consecutive failures")
+	}
+	// Call/Execute pass gobreaker's own Execute error through unchanged, so an
+	// open breaker surfaces as gobreaker.ErrOpenState here, not
+	// circuitbreaker.ErrCircuitOpen (that sentinel is only ever returned by
+	// AsyncCircuitBreaker.SubmitAsync's own pre-Execute open check).
+	if err := cb.Call(func() error { return nil }); !errors.Is(err, gobreaker.ErrOpenState) {
+		t.Fatalf("got %v, want gobreaker.ErrOpenState", err)
+	}
+}
+
+func TestCallOpensOnFailurePercentage(t *testing.T) {
+	cb := New("test", Config{
+		FailureThreshold:           1000, // disable the consecutive-failure trip so only the ratio policy can fire
+		FailureThresholdPercentage: 50,
+		MinRequestsThreshold:       4,
+		WindowDuration:             time.Minute,
+		Timeout:                    time.Minute,
+		MaxRequests:                1,
+	})
+
+	// gobreaker only calls ReadyToTrip on a failing request, so the 4th
+	// (failing) call is what has to push Requests to MinRequestsThreshold
+	// and TotalFailures/Requests to FailureThresholdPercentage at once.
+	results := []error{nil, nil, errFail, errFail}
+	for _, want := range results {
+		cb.Call(func() error { return want })
+	}
+
+	if !cb.IsOpen() {
+		t.Fatal("expected breaker to be open once failure ratio reached FailureThresholdPercentage")
+	}
+}
+
+var errFail = errors.New("fail")
+
+func TestReadyToTripIgnoresPercentageBelowMinRequests(t *testing.T) {
+	cb := New("test", Config{
+		FailureThreshold:           1000,
+		FailureThresholdPercentage: 50,
+		MinRequestsThreshold:       10,
+		WindowDuration:             time.Minute,
+		Timeout:                    time.Minute,
+		MaxRequests:                1,
+	})
+
+	for i := 0; i < 5; i++ {
+		cb.Call(func() error { return errFail })
+	}
+
+	if cb.IsOpen() {
+		t.Fatal("breaker should not trip before MinRequestsThreshold requests are observed")
+	}
+}
+
+func TestWarmingUpSuppressesTrip(t *testing.T) {
+	cb := New("test", Config{
+		FailureThreshold: 1,
+		Timeout:          time.Minute,
+		MaxRequests:      1,
+		InitialDelay:     time.Hour,
+	})
+
+	if !cb.WarmingUp() {
+		t.Fatal("expected a freshly created breaker to report WarmingUp")
+	}
+
+	for i := 0; i < 5; i++ {
+		cb.Call(func() error { return errFail })
+	}
+
+	if cb.IsOpen() {
+		t.Fatal("breaker should not trip while still inside InitialDelay, regardless of FailureThreshold")
+	}
+}
+
+func TestExecuteReturnsTypedResult(t *testing.T) {
+	cb := New("test", Config{FailureThreshold: 3, Timeout: time.Minute, MaxRequests: 1})
+
+	got, err := Execute(cb, func() (int, error) { return 42, nil })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 42 {
+		t.Fatalf("got %d, want 42", got)
+	}
+
+	_, err = Execute(cb, func() (int, error) { return 0, errFail })
+	if !errors.Is(err, errFail) {
+		t.Fatalf("got %v, want errFail", err)
+	}
+}
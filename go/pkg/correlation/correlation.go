@@ -2,33 +2,83 @@ package correlation
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/binary"
 	"fmt"
-	"math/rand"
+	"hash/fnv"
+	"sync/atomic"
 	"time"
 )
 
-// IDGenerator generates correlation IDs
+// base62Charset is the digit alphabet reverseBase62 encodes into.
+const base62Charset = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// IDGenerator generates correlation IDs. The zero value's counter is ready
+// to use; construct with NewIDGenerator to also set serviceName.
 type IDGenerator struct {
 	serviceName string
-	rng         *rand.Rand
+	counter     atomic.Uint64
 }
 
 // NewIDGenerator creates a new correlation ID generator
 func NewIDGenerator(serviceName string) *IDGenerator {
-	return &IDGenerator{
-		serviceName: serviceName,
-		rng:         rand.New(rand.NewSource(time.Now().UnixNano())),
-	}
+	return &IDGenerator{serviceName: serviceName}
+}
+
+// ServiceName returns the service name this generator stamps into every
+// correlation ID, so callers building a per-service Logger (e.g. the
+// correlation/middleware package) don't need their own copy of it.
+func (g *IDGenerator) ServiceName() string {
+	return g.serviceName
 }
 
-// Generate creates a new correlation ID
-// Format: {service}-{timestamp}-{random}
-// Example: api-1699564823-a3f9c2
+// Generate creates a new correlation ID.
+// Format: {service}-{reverseBase62(nanos)}{reverseBase62(counter)}
 // Requirement 8.1: Generate unique correlation ID
 func (g *IDGenerator) Generate() string {
-	timestamp := time.Now().Unix()
-	random := g.rng.Intn(0xFFFFFF) // 24-bit random number
-	return fmt.Sprintf("%s-%d-%06x", g.serviceName, timestamp, random)
+	return g.GenerateWithTime(time.Now())
+}
+
+// GenerateWithTime is Generate with an injected timestamp, for tests that
+// need deterministic or ordered IDs.
+func (g *IDGenerator) GenerateWithTime(t time.Time) string {
+	return fmt.Sprintf("%s-%s%s", g.serviceName, reverseBase62(uint64(t.UnixNano())), reverseBase62(g.nextCounter()))
+}
+
+// nextCounter combines a monotonically increasing atomic.Uint64 with 8
+// bytes of crypto/rand entropy, so IDs generated within the same nanosecond
+// across goroutines don't collide and don't leak a predictable sequence -
+// all without a mutex on the hot path.
+func (g *IDGenerator) nextCounter() uint64 {
+	var buf [8]byte
+	_, _ = rand.Read(buf[:])
+	return binary.BigEndian.Uint64(buf[:]) + g.counter.Add(1)
+}
+
+// DeriveOperationID deterministically derives an operation ID from
+// correlationID by hashing it, so a client retry that reuses the same
+// X-Correlation-ID produces the same operation ID every time - unlike
+// Generate, which mints a fresh, time-and-counter-based ID on every call
+// and can't be reproduced for a later retry of the same logical operation.
+func DeriveOperationID(correlationID string) string {
+	sum := fnv.New64a()
+	_, _ = sum.Write([]byte(correlationID))
+	return "op-" + reverseBase62(sum.Sum64())
+}
+
+// reverseBase62 encodes n in base62 least-significant-digit first. The
+// first character is the lowest digit, so the lexical order of two outputs
+// does not follow the numeric order of their inputs.
+func reverseBase62(n uint64) string {
+	if n == 0 {
+		return "0"
+	}
+	var buf []byte
+	for n > 0 {
+		buf = append(buf, base62Charset[n%62])
+		n /= 62
+	}
+	return string(buf)
 }
 
 // contextKey is a custom type for context keys to avoid collisions
@@ -37,6 +87,16 @@ type contextKey string
 const (
 	// CorrelationIDKey is the context key for correlation ID
 	CorrelationIDKey contextKey = "correlation_id"
+
+	// OperationIDKey is the context key for operation ID: a server-generated
+	// identifier, stable across retries of the same logical operation,
+	// never taken from the client.
+	OperationIDKey contextKey = "operation_id"
+
+	// ExternalIDKey is the context key for external ID: an opaque trace
+	// token supplied by the client (e.g. from an upstream system), carried
+	// through unchanged for cross-system correlation.
+	ExternalIDKey contextKey = "external_id"
 )
 
 // WithID adds correlation ID to context
@@ -51,6 +111,32 @@ func FromContext(ctx context.Context) (string, bool) {
 	return correlationID, ok
 }
 
+// WithOperationID adds a server-generated operation ID to context. Unlike
+// the correlation ID, this is never accepted from a client - callers should
+// derive it themselves via DeriveOperationID(correlationID) so it stays
+// stable across retries of the same logical operation (a fresh ID per call,
+// e.g. from IDGenerator.Generate, would give every retry a different one).
+func WithOperationID(ctx context.Context, operationID string) context.Context {
+	return context.WithValue(ctx, OperationIDKey, operationID)
+}
+
+// OperationIDFromContext retrieves the operation ID from context
+func OperationIDFromContext(ctx context.Context) (string, bool) {
+	operationID, ok := ctx.Value(OperationIDKey).(string)
+	return operationID, ok
+}
+
+// WithExternalID adds a client-supplied external trace token to context
+func WithExternalID(ctx context.Context, externalID string) context.Context {
+	return context.WithValue(ctx, ExternalIDKey, externalID)
+}
+
+// ExternalIDFromContext retrieves the external ID from context
+func ExternalIDFromContext(ctx context.Context) (string, bool) {
+	externalID, ok := ctx.Value(ExternalIDKey).(string)
+	return externalID, ok
+}
+
 // GetOrGenerate retrieves correlation ID from context or generates a new one
 func GetOrGenerate(ctx context.Context, generator *IDGenerator) (string, context.Context) {
 	if correlationID, ok := FromContext(ctx); ok && correlationID != "" {
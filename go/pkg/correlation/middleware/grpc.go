@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"stockfish-scale/pkg/correlation"
+)
+
+// grpcMetadataKey is the gRPC metadata key carrying the correlation ID,
+// lowercase per gRPC metadata convention.
+const grpcMetadataKey = "x-correlation-id"
+
+// UnaryServerInterceptor reads the x-correlation-id metadata key from an
+// incoming unary RPC (generating a new one via gen if missing/invalid),
+// injects it into the handler's context, and echoes it back as response
+// header metadata.
+func UnaryServerInterceptor(gen *correlation.IDGenerator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, id := withIncomingCorrelationID(ctx, gen)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(grpcMetadataKey, id))
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor does the same as UnaryServerInterceptor for
+// streaming RPCs. grpc.ServerStream has no settable context, so the stream
+// passed to handler is wrapped to override Context().
+func StreamServerInterceptor(gen *correlation.IDGenerator) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, id := withIncomingCorrelationID(ss.Context(), gen)
+		_ = ss.SetHeader(metadata.Pairs(grpcMetadataKey, id))
+		return handler(srv, &correlatedServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// withIncomingCorrelationID extracts the correlation ID from ctx's incoming
+// gRPC metadata, generating one via gen if it's missing or invalid, and
+// returns a context carrying it alongside the ID itself.
+func withIncomingCorrelationID(ctx context.Context, gen *correlation.IDGenerator) (context.Context, string) {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if vals := md.Get(grpcMetadataKey); len(vals) > 0 {
+			id = vals[0]
+		}
+	}
+	if !correlation.Validate(id) {
+		id = gen.Generate()
+	}
+	return correlation.WithID(ctx, id), id
+}
+
+// correlatedServerStream wraps a grpc.ServerStream to serve a context
+// carrying the correlation ID from Context().
+type correlatedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *correlatedServerStream) Context() context.Context {
+	return s.ctx
+}
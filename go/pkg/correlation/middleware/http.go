@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2025 Ishaan Nene
+ *
+ * This source code is licensed under the MIT license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+// Package middleware wires pkg/correlation's IDGenerator into real server
+// and client transports: an http.Handler middleware, a unary+stream gRPC
+// interceptor pair, and an http.RoundTripper that forwards the ID on
+// outgoing calls.
+package middleware
+
+import (
+	"net/http"
+
+	"stockfish-scale/pkg/correlation"
+	"stockfish-scale/pkg/logging"
+)
+
+// DefaultExternalIDHeader is the header HTTPMiddleware reads the client-
+// supplied external_id trace token from when no override is given.
+const DefaultExternalIDHeader = "X-External-ID"
+
+// HTTPMiddleware reads the correlation ID from the X-Correlation-ID header
+// (falling back to X-Request-ID), generating a new one via gen if neither is
+// present or valid. It also stamps a server-generated operation_id (never
+// taken from the client) and, if present, carries through the client's
+// external_id from DefaultExternalIDHeader. See HTTPMiddlewareWithConfig to
+// read external_id from a different header.
+// Requirement 8.1, 8.2, 8.3, 8.5: correlation ID on every request.
+func HTTPMiddleware(next http.Handler, gen *correlation.IDGenerator) http.Handler {
+	return HTTPMiddlewareWithConfig(next, gen, DefaultExternalIDHeader)
+}
+
+// HTTPMiddlewareWithConfig is HTTPMiddleware with a configurable header for
+// the client-supplied external_id trace token. All three identifiers are
+// injected into the request context, the correlation and operation IDs are
+// echoed back in response headers (external_id is not), and a
+// Logger carrying all three (via logging.Logger.WithContext) is stashed in
+// context via logging.WithLoggerContext so downstream handlers can pull one
+// out instead of rebuilding it.
+func HTTPMiddlewareWithConfig(next http.Handler, gen *correlation.IDGenerator, externalIDHeader string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get("X-Correlation-ID")
+		if !correlation.Validate(id) {
+			id = r.Header.Get("X-Request-ID")
+		}
+		if !correlation.Validate(id) {
+			id = gen.Generate()
+		}
+
+		// operation_id is derived from the (server-generated-or-validated)
+		// correlation ID rather than minted fresh, so a client retry that
+		// reuses the same X-Correlation-ID produces the same operation_id
+		// instead of a new one every attempt.
+		operationID := correlation.DeriveOperationID(id)
+
+		ctx := correlation.WithID(r.Context(), id)
+		ctx = correlation.WithOperationID(ctx, operationID)
+		if externalID := r.Header.Get(externalIDHeader); correlation.Validate(externalID) {
+			ctx = correlation.WithExternalID(ctx, externalID)
+		}
+
+		ctx = logging.WithLoggerContext(ctx, logging.NewLogger(gen.ServiceName()).WithContext(ctx))
+		r = r.WithContext(ctx)
+
+		w.Header().Set("X-Correlation-ID", id)
+		w.Header().Set("X-Operation-ID", operationID)
+
+		next.ServeHTTP(w, r)
+	})
+}
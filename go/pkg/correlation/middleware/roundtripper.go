@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"net/http"
+
+	"stockfish-scale/pkg/correlation"
+)
+
+// RoundTripper wraps Next, copying the correlation ID from an outgoing
+// request's context into the X-Correlation-ID header so downstream HTTP
+// calls preserve it end-to-end. A nil Next falls back to
+// http.DefaultTransport.
+type RoundTripper struct {
+	Next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (rt *RoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if id, ok := correlation.FromContext(req.Context()); ok && correlation.Validate(id) {
+		req = req.Clone(req.Context())
+		req.Header.Set("X-Correlation-ID", id)
+	}
+
+	next := rt.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return next.RoundTrip(req)
+}
@@ -0,0 +1,59 @@
+// Package cputime reports the calling process's own CPU time consumption
+// across platforms. It replaces ad-hoc procfs parsing with a Source
+// selected once for the running GOOS, falling back to getrusage(RUSAGE_SELF)
+// when the platform-specific source errors.
+package cputime
+
+import "time"
+
+// Times is a process's CPU time breakdown, as reported by a Source.
+type Times struct {
+	User   time.Duration
+	System time.Duration
+	Total  time.Duration
+}
+
+// Source reports the calling process's own CPU time consumption.
+type Source interface {
+	Times() (Times, error)
+}
+
+// New returns the Source appropriate for runtime.GOOS, wrapped with a
+// getrusage(RUSAGE_SELF) fallback for when the platform source fails.
+func New() Source {
+	return &fallbackSource{primary: platformSource(), fallback: rusageSource{}}
+}
+
+// fallbackSource tries primary first and only falls back on error, so the
+// richer per-category breakdown a platform source can offer (e.g. procfs's
+// separate utime/stime) isn't discarded on the common path.
+type fallbackSource struct {
+	primary  Source
+	fallback Source
+}
+
+func (s *fallbackSource) Times() (Times, error) {
+	t, err := s.primary.Times()
+	if err == nil {
+		return t, nil
+	}
+	return s.fallback.Times()
+}
+
+// chainSource tries each Source in order, returning the first that
+// succeeds. Used by platformSource to prefer a more accurate source (e.g.
+// cgroup accounting) over a coarser one (e.g. procfs) on platforms with
+// more than one.
+type chainSource []Source
+
+func (c chainSource) Times() (Times, error) {
+	var lastErr error
+	for _, s := range c {
+		t, err := s.Times()
+		if err == nil {
+			return t, nil
+		}
+		lastErr = err
+	}
+	return Times{}, lastErr
+}
@@ -0,0 +1,11 @@
+//go:build darwin
+
+package cputime
+
+// On Darwin the most portable per-process CPU time source without cgo is
+// getrusage(RUSAGE_SELF) -- the same call gopsutil's process.Times() falls
+// back to on this platform -- so the platform source and the cross-platform
+// fallback are the same implementation here.
+func platformSource() Source {
+	return rusageSource{}
+}
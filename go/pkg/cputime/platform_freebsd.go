@@ -0,0 +1,69 @@
+//go:build freebsd
+
+package cputime
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// racctSource reads per-jail CPU time accounting via the rctl(8) resource
+// limits and accounting facility (racct), keyed by the jail or container
+// name the process is running under -- e.g. the jail name Podman assigns on
+// FreeBSD hosts, mirroring the jail-aware accounting in stats_freebsd.go
+// rather than reading global host stats that would double-count sibling
+// jails.
+type racctSource struct{}
+
+func platformSource() Source {
+	return racctSource{}
+}
+
+// jailName resolves the jail this process should be accounted under,
+// preferring an explicit override for runtimes (Podman) that don't expose
+// their assigned jail name any other way.
+func jailName() (string, error) {
+	if name := os.Getenv("PODMAN_JAIL_NAME"); name != "" {
+		return name, nil
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", fmt.Errorf("cputime: resolve jail name: %w", err)
+	}
+	return hostname, nil
+}
+
+func (racctSource) Times() (Times, error) {
+	jail, err := jailName()
+	if err != nil {
+		return Times{}, err
+	}
+
+	out, err := exec.Command("rctl", "-u", "jail:"+jail).Output()
+	if err != nil {
+		return Times{}, fmt.Errorf("cputime: rctl jail:%s: %w", jail, err)
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "cputime=") {
+			continue
+		}
+		seconds, err := strconv.ParseInt(strings.TrimPrefix(line, "cputime="), 10, 64)
+		if err != nil {
+			return Times{}, fmt.Errorf("cputime: parse rctl cputime: %w", err)
+		}
+		// racct's cputime rule reports combined user+system jail CPU time;
+		// it doesn't break the two down the way procfs or getrusage do.
+		total := time.Duration(seconds) * time.Second
+		return Times{Total: total}, nil
+	}
+
+	return Times{}, fmt.Errorf("cputime: jail:%s has no racct cputime rule", jail)
+}
@@ -0,0 +1,40 @@
+//go:build windows
+
+package cputime
+
+import (
+	"fmt"
+	"syscall"
+	"time"
+)
+
+// winSource reads CPU time via the Win32 GetProcessTimes API, the same call
+// gopsutil's process.Times() makes on Windows.
+type winSource struct{}
+
+func platformSource() Source {
+	return winSource{}
+}
+
+func (winSource) Times() (Times, error) {
+	handle, err := syscall.GetCurrentProcess()
+	if err != nil {
+		return Times{}, fmt.Errorf("cputime: GetCurrentProcess: %w", err)
+	}
+
+	var creation, exit, kernel, user syscall.Filetime
+	if err := syscall.GetProcessTimes(handle, &creation, &exit, &kernel, &user); err != nil {
+		return Times{}, fmt.Errorf("cputime: GetProcessTimes: %w", err)
+	}
+
+	userDur := filetimeToDuration(user)
+	kernelDur := filetimeToDuration(kernel)
+	return Times{User: userDur, System: kernelDur, Total: userDur + kernelDur}, nil
+}
+
+// filetimeToDuration converts a FILETIME (100-nanosecond intervals since
+// 1601) into the duration it represents.
+func filetimeToDuration(ft syscall.Filetime) time.Duration {
+	ticks := int64(ft.HighDateTime)<<32 | int64(ft.LowDateTime)
+	return time.Duration(ticks * 100)
+}
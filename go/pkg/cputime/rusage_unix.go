@@ -0,0 +1,24 @@
+//go:build linux || darwin || freebsd
+
+package cputime
+
+import (
+	"fmt"
+	"syscall"
+	"time"
+)
+
+// rusageSource reads getrusage(RUSAGE_SELF), the lowest-common-denominator
+// fallback shared by every unix platformSource in this package.
+type rusageSource struct{}
+
+func (rusageSource) Times() (Times, error) {
+	var ru syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &ru); err != nil {
+		return Times{}, fmt.Errorf("cputime: getrusage: %w", err)
+	}
+
+	user := time.Duration(ru.Utime.Sec)*time.Second + time.Duration(ru.Utime.Usec)*time.Microsecond
+	system := time.Duration(ru.Stime.Sec)*time.Second + time.Duration(ru.Stime.Usec)*time.Microsecond
+	return Times{User: user, System: system, Total: user + system}, nil
+}
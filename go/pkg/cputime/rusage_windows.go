@@ -0,0 +1,14 @@
+//go:build windows
+
+package cputime
+
+import "fmt"
+
+// rusageSource has no Windows equivalent -- GetProcessTimes already is the
+// platform source there -- so this only exists to satisfy New()'s generic
+// fallback wiring.
+type rusageSource struct{}
+
+func (rusageSource) Times() (Times, error) {
+	return Times{}, fmt.Errorf("cputime: getrusage(RUSAGE_SELF) is not available on windows")
+}
@@ -0,0 +1,322 @@
+// Package enginepool maintains a small set of warm, UCI-handshaked TCP
+// connections to a Stockfish engine address so that handleJob no longer
+// pays a fresh dial plus "uci"/"uciok" roundtrip on every job.
+package enginepool
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sony/gobreaker"
+)
+
+// Config controls pool sizing, connection lifetime, and health checking.
+type Config struct {
+	Size              int           // number of warm connections maintained
+	MaxLifetime       time.Duration // connections older than this are evicted on next acquire
+	HealthCheckPeriod time.Duration // how often idle connections are pinged; 0 disables checks
+	AcquireTimeout    time.Duration // how long Acquire waits for a free slot; 0 waits forever
+}
+
+// DefaultConfig returns pool settings suitable for a single Stockfish engine.
+func DefaultConfig() Config {
+	return Config{
+		Size:              4,
+		MaxLifetime:       10 * time.Minute,
+		HealthCheckPeriod: 30 * time.Second,
+		AcquireTimeout:    5 * time.Second,
+	}
+}
+
+// StatsCollector receives pool telemetry, implemented by pkg/metrics.
+type StatsCollector interface {
+	SetEnginePoolInUse(addr string, count float64)
+	SetEnginePoolIdle(addr string, count float64)
+	RecordEnginePoolWaitTime(addr string, wait time.Duration)
+	IncrementEnginePoolEvictions(addr string)
+}
+
+// Conn is a pooled, already-handshaked Stockfish connection. Callers that
+// finish a computation successfully should call Release; callers that
+// suspect the connection is in a bad protocol state should call Discard
+// instead so the pool dials a fresh replacement.
+type Conn struct {
+	net.Conn
+	Reader    *bufio.Reader
+	createdAt time.Time
+	pool      *Pool
+}
+
+// Release returns a healthy connection to its pool for reuse.
+func (c *Conn) Release() {
+	c.pool.release(c)
+}
+
+// Discard closes the connection instead of returning it to the pool.
+func (c *Conn) Discard() {
+	c.pool.discard(c)
+}
+
+// Ping issues "isready" and waits for "readyok", used both for idle health
+// checks and by callers that want to confirm a connection before reuse.
+func (c *Conn) Ping(timeout time.Duration) error {
+	if _, err := fmt.Fprintf(c.Conn, "isready\n"); err != nil {
+		return fmt.Errorf("enginepool: isready: %w", err)
+	}
+	return c.readUntil("readyok", timeout)
+}
+
+// handshake runs the one-time "uci"/"uciok" exchange for a freshly dialed
+// connection, so acquisitions afterward can skip straight to ucinewgame.
+func (c *Conn) handshake() error {
+	if _, err := fmt.Fprintf(c.Conn, "uci\n"); err != nil {
+		return fmt.Errorf("enginepool: uci: %w", err)
+	}
+	return c.readUntil("uciok", 3*time.Second)
+}
+
+// readUntil reads lines until one contains substr or timeout elapses.
+func (c *Conn) readUntil(substr string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		c.Conn.SetReadDeadline(time.Now().Add(500 * time.Millisecond))
+		line, err := c.Reader.ReadString('\n')
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Timeout() {
+				continue
+			}
+			return err
+		}
+		if strings.Contains(line, substr) {
+			return nil
+		}
+	}
+	return fmt.Errorf("enginepool: timeout waiting for %q", substr)
+}
+
+// Pool maintains Config.Size warm, handshaked connections to a single
+// Stockfish engine address, with a bounded FIFO wait queue for acquirers
+// beyond that size and background idle health checking.
+type Pool struct {
+	addr  string
+	cfg   Config
+	cb    *gobreaker.CircuitBreaker
+	stats StatsCollector
+
+	mu    sync.Mutex
+	idle  []*Conn
+	inUse int
+
+	// slots is a buffered channel seeded with Config.Size tokens; acquiring a
+	// token (in the order callers arrive) is what makes the wait queue FIFO.
+	slots chan struct{}
+
+	closeOnce sync.Once
+	closeCh   chan struct{}
+}
+
+// New creates a pool for addr and starts its background health-check loop.
+func New(addr string, cfg Config, cb *gobreaker.CircuitBreaker, stats StatsCollector) *Pool {
+	if cfg.Size <= 0 {
+		cfg.Size = 1
+	}
+
+	p := &Pool{
+		addr:    addr,
+		cfg:     cfg,
+		cb:      cb,
+		stats:   stats,
+		slots:   make(chan struct{}, cfg.Size),
+		closeCh: make(chan struct{}),
+	}
+	for i := 0; i < cfg.Size; i++ {
+		p.slots <- struct{}{}
+	}
+
+	go p.healthCheckLoop()
+
+	return p
+}
+
+// Acquire waits for a free slot (FIFO, bounded by Config.Size), then returns
+// an idle warm connection or dials and handshakes a new one. Acquisition is
+// wrapped in the circuit breaker so an unreachable engine fails fast instead
+// of exhausting the wait queue on every caller.
+func (p *Pool) Acquire(ctx context.Context) (*Conn, error) {
+	waitStart := time.Now()
+
+	acquireCtx := ctx
+	if p.cfg.AcquireTimeout > 0 {
+		var cancel context.CancelFunc
+		acquireCtx, cancel = context.WithTimeout(ctx, p.cfg.AcquireTimeout)
+		defer cancel()
+	}
+
+	select {
+	case <-p.slots:
+	case <-acquireCtx.Done():
+		return nil, fmt.Errorf("enginepool: timed out waiting for a connection slot: %w", acquireCtx.Err())
+	}
+
+	if p.stats != nil {
+		p.stats.RecordEnginePoolWaitTime(p.addr, time.Since(waitStart))
+	}
+
+	result, err := p.cb.Execute(func() (interface{}, error) {
+		return p.take()
+	})
+	if err != nil {
+		// Acquisition failed before a connection left the pool; give the
+		// slot back so the next waiter isn't starved by our failure.
+		p.slots <- struct{}{}
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.inUse++
+	p.publishStatsLocked()
+	p.mu.Unlock()
+
+	return result.(*Conn), nil
+}
+
+// take pops a non-expired idle connection, evicting any that have outlived
+// Config.MaxLifetime, or dials and handshakes a new one if none are idle.
+func (p *Pool) take() (*Conn, error) {
+	p.mu.Lock()
+	for len(p.idle) > 0 {
+		c := p.idle[len(p.idle)-1]
+		p.idle = p.idle[:len(p.idle)-1]
+
+		if p.cfg.MaxLifetime > 0 && time.Since(c.createdAt) > p.cfg.MaxLifetime {
+			p.mu.Unlock()
+			c.Conn.Close()
+			if p.stats != nil {
+				p.stats.IncrementEnginePoolEvictions(p.addr)
+			}
+			p.mu.Lock()
+			continue
+		}
+
+		p.mu.Unlock()
+		return c, nil
+	}
+	p.mu.Unlock()
+
+	return p.dial()
+}
+
+func (p *Pool) dial() (*Conn, error) {
+	netConn, err := net.DialTimeout("tcp", p.addr, 5*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("enginepool: dial %s: %w", p.addr, err)
+	}
+
+	c := &Conn{
+		Conn:      netConn,
+		Reader:    bufio.NewReader(netConn),
+		createdAt: time.Now(),
+		pool:      p,
+	}
+	if err := c.handshake(); err != nil {
+		netConn.Close()
+		return nil, fmt.Errorf("enginepool: handshake with %s: %w", p.addr, err)
+	}
+	return c, nil
+}
+
+func (p *Pool) release(c *Conn) {
+	p.mu.Lock()
+	p.idle = append(p.idle, c)
+	p.inUse--
+	p.publishStatsLocked()
+	p.mu.Unlock()
+
+	p.slots <- struct{}{}
+}
+
+func (p *Pool) discard(c *Conn) {
+	c.Conn.Close()
+
+	p.mu.Lock()
+	p.inUse--
+	p.publishStatsLocked()
+	p.mu.Unlock()
+
+	p.slots <- struct{}{}
+}
+
+// publishStatsLocked reports current pool occupancy. Callers must hold p.mu.
+func (p *Pool) publishStatsLocked() {
+	if p.stats == nil {
+		return
+	}
+	p.stats.SetEnginePoolInUse(p.addr, float64(p.inUse))
+	p.stats.SetEnginePoolIdle(p.addr, float64(len(p.idle)))
+}
+
+// healthCheckLoop periodically pings idle connections and drops any that
+// fail to respond, so a dead engine process doesn't silently poison the pool.
+func (p *Pool) healthCheckLoop() {
+	if p.cfg.HealthCheckPeriod <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(p.cfg.HealthCheckPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.closeCh:
+			return
+		case <-ticker.C:
+			p.checkIdle()
+		}
+	}
+}
+
+// checkIdle pings every idle connection, holding p.mu for the whole pass
+// instead of evacuating p.idle under no lock: each Ping has its own short
+// (2s) timeout, so the pause this imposes on Acquire/release/discard is
+// bounded, and it keeps p.idle from ever being observably empty to a
+// racing Acquire - which would otherwise see no idle connections and dial
+// a brand-new one, transiently exceeding Config.Size live connections.
+func (p *Pool) checkIdle() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	healthy := p.idle[:0]
+	for _, c := range p.idle {
+		if err := c.Ping(2 * time.Second); err != nil {
+			c.Conn.Close()
+			if p.stats != nil {
+				p.stats.IncrementEnginePoolEvictions(p.addr)
+			}
+			continue
+		}
+		healthy = append(healthy, c)
+	}
+	p.idle = healthy
+	p.publishStatsLocked()
+}
+
+// Close stops the health-check loop and closes all idle connections. It does
+// not wait for in-use connections; callers should Release or Discard those
+// themselves as their jobs complete.
+func (p *Pool) Close() {
+	p.closeOnce.Do(func() {
+		close(p.closeCh)
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		for _, c := range p.idle {
+			c.Conn.Close()
+		}
+		p.idle = nil
+	})
+}
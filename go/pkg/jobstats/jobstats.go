@@ -0,0 +1,250 @@
+// Package jobstats tracks the lifecycle of worker jobs in Redis so that an
+// in-flight job surviving a worker crash or kill can be detected and
+// recovered on restart, instead of silently disappearing after being popped
+// from the queue.
+package jobstats
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"stockfish-scale/pkg/keys"
+)
+
+// Status is the lifecycle state of a tracked job.
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusSuccess   Status = "success"
+	StatusFailed    Status = "failed"
+	StatusCancelled Status = "cancelled"
+
+	// StatusRequeued marks a job RecoverStale found abandoned by a dead
+	// worker and handed back to the queue, but that no live worker has
+	// claimed yet. RecoverStale treats it neither as pending nor running, so
+	// the same entry can't be matched - and requeued again - by a second
+	// scan before Claim overwrites it back to StatusPending once an actual
+	// consumer dequeues it.
+	StatusRequeued Status = "requeued"
+)
+
+// JobState is the Redis hash representation of a job's lifecycle, returned
+// by Get and exposed on the /jobs/{id} endpoint.
+type JobState struct {
+	JobID         string `json:"job_id"`
+	Status        Status `json:"status"`
+	WorkerID      string `json:"worker_id"`
+	Payload       string `json:"payload"`
+	StartedAt     string `json:"started_at"`
+	LastHeartbeat string `json:"last_heartbeat"`
+	CompletedAt   string `json:"completed_at,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+// Manager tracks job lifecycle state in per-job Redis hashes, keyed by job
+// ID, plus a per-shard set of currently active (pending/running/requeued) job IDs
+// used for crash recovery scans. rdb is a redis.UniversalClient so the same
+// Manager works unmodified against single-node Redis or a Cluster, since
+// every key it touches is built by pkg/keys behind a shard hash tag.
+type Manager struct {
+	rdb      redis.UniversalClient
+	workerID string
+}
+
+// NewManager creates a job lifecycle tracker that attributes claimed jobs to
+// workerID.
+func NewManager(rdb redis.UniversalClient, workerID string) *Manager {
+	return &Manager{rdb: rdb, workerID: workerID}
+}
+
+// Claim records that this worker has dequeued jobID, writing the initial
+// hash in status pending and indexing it in its shard's active set. Both
+// keys share jobID's shard tag, so they stay on the same Cluster slot.
+func (m *Manager) Claim(ctx context.Context, jobID, payload string) error {
+	now := time.Now().Format(time.RFC3339Nano)
+	err := m.rdb.HSet(ctx, keys.Job(jobID), map[string]interface{}{
+		"job_id":         jobID,
+		"status":         string(StatusPending),
+		"worker_id":      m.workerID,
+		"payload":        payload,
+		"started_at":     now,
+		"last_heartbeat": now,
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("jobstats: claim %s: %w", jobID, err)
+	}
+	if err := m.rdb.SAdd(ctx, keys.ActiveSet(keys.ShardForJobID(jobID)), jobID).Err(); err != nil {
+		return fmt.Errorf("jobstats: index %s: %w", jobID, err)
+	}
+	return nil
+}
+
+// MarkRunning transitions jobID from pending to running, once handleJob
+// actually begins work on it.
+func (m *Manager) MarkRunning(ctx context.Context, jobID string) error {
+	err := m.rdb.HSet(ctx, keys.Job(jobID), map[string]interface{}{
+		"status":         string(StatusRunning),
+		"last_heartbeat": time.Now().Format(time.RFC3339Nano),
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("jobstats: mark running %s: %w", jobID, err)
+	}
+	return nil
+}
+
+// Heartbeat refreshes the last-seen timestamp so a recovery scan can tell a
+// live job apart from one abandoned by a dead worker.
+func (m *Manager) Heartbeat(ctx context.Context, jobID string) error {
+	return m.rdb.HSet(ctx, keys.Job(jobID), "last_heartbeat", time.Now().Format(time.RFC3339Nano)).Err()
+}
+
+// StartHeartbeat calls Heartbeat on the given interval until stop is closed
+// or ctx is done. It is meant to run in its own goroutine alongside a job
+// blocked waiting on Stockfish.
+func (m *Manager) StartHeartbeat(ctx context.Context, jobID string, interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		case <-ticker.C:
+			m.Heartbeat(ctx, jobID)
+		}
+	}
+}
+
+// Complete transitions jobID to a terminal status and removes it from the
+// active index.
+func (m *Manager) Complete(ctx context.Context, jobID string, status Status, errMsg string) error {
+	fields := map[string]interface{}{
+		"status":       string(status),
+		"completed_at": time.Now().Format(time.RFC3339Nano),
+	}
+	if errMsg != "" {
+		fields["error"] = errMsg
+	}
+	if err := m.rdb.HSet(ctx, keys.Job(jobID), fields).Err(); err != nil {
+		return fmt.Errorf("jobstats: complete %s: %w", jobID, err)
+	}
+	if err := m.rdb.SRem(ctx, keys.ActiveSet(keys.ShardForJobID(jobID)), jobID).Err(); err != nil {
+		return fmt.Errorf("jobstats: deindex %s: %w", jobID, err)
+	}
+	return nil
+}
+
+// MarkRequeued transitions jobID to StatusRequeued and refreshes its
+// heartbeat, after RecoverStale has handed it back to the queue on behalf of
+// a dead worker. It leaves jobID in the active index (the job is still
+// in-flight, just not owned by anyone yet) so CountByStatus keeps counting
+// it, but out of the StatusRunning/StatusPending set RecoverStale's own scan
+// matches against, so the same abandoned entry can't be requeued a second
+// time before a live worker actually claims it.
+func (m *Manager) MarkRequeued(ctx context.Context, jobID string) error {
+	err := m.rdb.HSet(ctx, keys.Job(jobID), map[string]interface{}{
+		"status":         string(StatusRequeued),
+		"last_heartbeat": time.Now().Format(time.RFC3339Nano),
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("jobstats: mark requeued %s: %w", jobID, err)
+	}
+	return nil
+}
+
+// Get returns the tracked state for jobID. It returns redis.Nil if jobID has
+// no hash (never claimed, or expired).
+func (m *Manager) Get(ctx context.Context, jobID string) (*JobState, error) {
+	res, err := m.rdb.HGetAll(ctx, keys.Job(jobID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, redis.Nil
+	}
+
+	return &JobState{
+		JobID:         res["job_id"],
+		Status:        Status(res["status"]),
+		WorkerID:      res["worker_id"],
+		Payload:       res["payload"],
+		StartedAt:     res["started_at"],
+		LastHeartbeat: res["last_heartbeat"],
+		CompletedAt:   res["completed_at"],
+		Error:         res["error"],
+	}, nil
+}
+
+// activeJobIDs collects the active-job index across every virtual shard,
+// since jobs are distributed across shards by hash and there is no single
+// active set that holds all of them.
+func (m *Manager) activeJobIDs(ctx context.Context) ([]string, error) {
+	var ids []string
+	for _, shard := range keys.AllShards() {
+		shardIDs, err := m.rdb.SMembers(ctx, keys.ActiveSet(shard)).Result()
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, shardIDs...)
+	}
+	return ids, nil
+}
+
+// CountByStatus tallies active (pending/running/requeued) jobs by status, meant to be
+// polled periodically to feed Prometheus gauges.
+func (m *Manager) CountByStatus(ctx context.Context) (map[Status]int, error) {
+	ids, err := m.activeJobIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[Status]int{StatusPending: 0, StatusRunning: 0, StatusRequeued: 0}
+	for _, id := range ids {
+		state, err := m.Get(ctx, id)
+		if err != nil {
+			continue
+		}
+		counts[state.Status]++
+	}
+	return counts, nil
+}
+
+// RecoverStale scans the active job index across every shard for entries
+// that either belong to this worker (it crashed and restarted) or whose
+// heartbeat has expired beyond heartbeatTimeout (its owner is presumed
+// dead), so the caller can requeue or fail them instead of leaving them
+// stuck forever.
+func (m *Manager) RecoverStale(ctx context.Context, heartbeatTimeout time.Duration) ([]*JobState, error) {
+	ids, err := m.activeJobIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var stale []*JobState
+	for _, id := range ids {
+		state, err := m.Get(ctx, id)
+		if err != nil {
+			continue
+		}
+		if state.Status != StatusRunning && state.Status != StatusPending {
+			continue
+		}
+
+		expired := true
+		if hb, err := time.Parse(time.RFC3339Nano, state.LastHeartbeat); err == nil {
+			expired = time.Since(hb) > heartbeatTimeout
+		}
+
+		if state.WorkerID == m.workerID || expired {
+			stale = append(stale, state)
+		}
+	}
+	return stale, nil
+}
@@ -0,0 +1,106 @@
+package jobstats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+func newTestManager(t *testing.T, workerID string) *Manager {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	return NewManager(rdb, workerID)
+}
+
+func TestRecoverStaleMatchesJobsOwnedByThisWorker(t *testing.T) {
+	ctx := context.Background()
+	m := newTestManager(t, "worker-a")
+
+	if err := m.Claim(ctx, "job-1", `{}`); err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+	if err := m.MarkRunning(ctx, "job-1"); err != nil {
+		t.Fatalf("MarkRunning: %v", err)
+	}
+
+	// Same worker ID as this Manager, so RecoverStale should treat it as a
+	// crash-and-restart case regardless of heartbeat freshness.
+	stale, err := m.RecoverStale(ctx, time.Hour)
+	if err != nil {
+		t.Fatalf("RecoverStale: %v", err)
+	}
+	if len(stale) != 1 || stale[0].JobID != "job-1" {
+		t.Fatalf("RecoverStale = %v, want [job-1]", stale)
+	}
+}
+
+func TestMarkRequeuedPreventsDuplicateRecovery(t *testing.T) {
+	ctx := context.Background()
+	m := newTestManager(t, "worker-a")
+
+	if err := m.Claim(ctx, "job-1", `{}`); err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+	if err := m.MarkRunning(ctx, "job-1"); err != nil {
+		t.Fatalf("MarkRunning: %v", err)
+	}
+
+	stale, err := m.RecoverStale(ctx, time.Hour)
+	if err != nil || len(stale) != 1 {
+		t.Fatalf("RecoverStale before requeue = %v, %v, want exactly job-1", stale, err)
+	}
+
+	// This is what recoverStaleJobs does once it has successfully XAdd'd the
+	// job back onto the stream: mark it requeued so a second recovery scan,
+	// before any worker has actually reclaimed it, doesn't match the same
+	// dead WorkerID and stale heartbeat and requeue it again.
+	if err := m.MarkRequeued(ctx, "job-1"); err != nil {
+		t.Fatalf("MarkRequeued: %v", err)
+	}
+
+	stale, err = m.RecoverStale(ctx, time.Hour)
+	if err != nil {
+		t.Fatalf("RecoverStale after requeue: %v", err)
+	}
+	if len(stale) != 0 {
+		t.Fatalf("RecoverStale after MarkRequeued = %v, want none (regression: duplicate recovery)", stale)
+	}
+
+	// Once a worker actually dequeues the requeued entry, Claim overwrites
+	// the hash back to pending, re-entering RecoverStale's match set.
+	if err := m.Claim(ctx, "job-1", `{}`); err != nil {
+		t.Fatalf("re-Claim: %v", err)
+	}
+	stale, err = m.RecoverStale(ctx, time.Hour)
+	if err != nil {
+		t.Fatalf("RecoverStale after re-Claim: %v", err)
+	}
+	if len(stale) != 1 {
+		t.Fatalf("RecoverStale after re-Claim = %v, want [job-1] again", stale)
+	}
+}
+
+func TestCompleteRemovesFromActiveIndex(t *testing.T) {
+	ctx := context.Background()
+	m := newTestManager(t, "worker-a")
+
+	if err := m.Claim(ctx, "job-1", `{}`); err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+	if err := m.Complete(ctx, "job-1", StatusSuccess, ""); err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+
+	stale, err := m.RecoverStale(ctx, time.Hour)
+	if err != nil {
+		t.Fatalf("RecoverStale: %v", err)
+	}
+	if len(stale) != 0 {
+		t.Fatalf("RecoverStale after Complete = %v, want none", stale)
+	}
+}
@@ -2,39 +2,98 @@ package k8s
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"sync"
 	"time"
 
 	"stockfish-scale/pkg/logging"
 	"stockfish-scale/pkg/metrics"
-	
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+	appsinformers "k8s.io/client-go/informers/apps/v1"
+	autoscalingv2informers "k8s.io/client-go/informers/autoscaling/v2"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
 )
 
-// ReplicaTracker tracks replica counts and calculates averages
-// Requirement 5.4: Query Kubernetes API for current replica counts and calculate average replicas over 1-hour windows
+// defaultLabelSelector scopes the deployment/replicaset informers to
+// resources explicitly opted into tracking. Replaces the old hardcoded
+// ["api", "worker", "stockfish"] deployment name list - any deployment
+// (and its owned ReplicaSets) carrying this label is picked up
+// automatically as it's created, no redeploy of this tracker required.
+const defaultLabelSelector = "stockfish-scale.io/tracked=true"
+
+// resyncPeriod is how often the informer cache replays a full List as a
+// safety net against a missed watch event, independent of the per-object
+// AddFunc/UpdateFunc delivery this package relies on for freshness.
+const resyncPeriod = 10 * time.Minute
+
+// scaledObjectGVR identifies KEDA's ScaledObject CRD. KEDA is optional -
+// NewReplicaTracker probes for it via discovery and leaves
+// scaledObjectInformer nil if the CRD isn't registered, so a cluster without
+// KEDA installed still gets plain HPA correlation.
+var scaledObjectGVR = schema.GroupVersionResource{
+	Group:    "keda.sh",
+	Version:  "v1alpha1",
+	Resource: "scaledobjects",
+}
+
+// ReplicaTracker watches tracked Deployments (and their owned ReplicaSets)
+// via a shared informer factory and maintains replica count history and
+// scaling event counters from the resulting Add/Update events.
+// Requirement 5.4: track current and 1-hour-average replica counts per deployment
 type ReplicaTracker struct {
-	clientset       *kubernetes.Clientset
-	namespace       string
-	metricsCol      *metrics.MetricsCollector
-	logger          logging.Logger
-	stopChan        chan struct{}
-	
+	clientset     *kubernetes.Clientset
+	namespace     string
+	labelSelector string
+	metricsCol    *metrics.MetricsCollector
+	logger        logging.Logger
+
+	factory            informers.SharedInformerFactory
+	deploymentInformer appsinformers.DeploymentInformer
+	replicaSetInformer appsinformers.ReplicaSetInformer
+	hpaInformer        autoscalingv2informers.HorizontalPodAutoscalerInformer
+
+	// KEDA ScaledObject watch, nil if the keda.sh/v1alpha1 CRD isn't
+	// registered in this cluster (KEDA not installed)
+	dynamicClient        dynamic.Interface
+	dynamicFactory       dynamicinformer.DynamicSharedInformerFactory
+	scaledObjectInformer cache.SharedIndexInformer
+
+	cancel context.CancelFunc
+
 	// Replica count history for calculating averages
-	replicaHistory  map[string][]replicaSnapshot
-	historyMu       sync.RWMutex
-	
+	replicaHistory map[string][]replicaSnapshot
+	historyMu      sync.RWMutex
+
 	// Last known replica counts for detecting scaling events (Requirement 5.8)
 	lastReplicaCounts map[string]int32
 	lastCountsMu      sync.RWMutex
-	
-	// Scaling event counters for ratio calculation (Requirement 5.8)
-	scaleUpCounts   map[string]int64
-	scaleDownCounts map[string]int64
-	scalingCountsMu sync.RWMutex
+
+	// Scaling event ring buffer for windowed ratio calculation (Requirement
+	// 5.8): each deployment's slice holds its scale events in timestamp
+	// order, trimmed to the largest window in scalingRatioWindows on every
+	// append, so updateScalingRatio can compute each window's ratio from
+	// current events instead of an unbounded lifetime counter.
+	scalingEventLog   map[string][]scalingEventRecord
+	scalingEventLogMu sync.RWMutex
+
+	// Most recent ScalingActive/ScalingLimited condition reason per
+	// deployment, keyed by the autoscaler's scale target name, so
+	// detectScalingEvent can annotate scaling_event_cause_total without
+	// re-deriving it from the informer cache on every event.
+	scalingCauses map[string]string
+	causesMu      sync.RWMutex
 }
 
 type replicaSnapshot struct {
@@ -42,7 +101,32 @@ type replicaSnapshot struct {
 	count     int32
 }
 
-// NewReplicaTracker creates a new replica tracker
+// scalingEventRecord is one scale-up or scale-down observation in a
+// deployment's scaling event ring buffer.
+type scalingEventRecord struct {
+	timestamp time.Time
+	direction string // "up" or "down"
+}
+
+// scalingRatioWindow is one rolling window updateScalingRatio reports a
+// scale-up/scale-down ratio for.
+type scalingRatioWindow struct {
+	label    string
+	duration time.Duration
+}
+
+// scalingRatioWindows are the rolling windows exported for every tracked
+// deployment, widest last so its duration bounds how much history
+// recordScalingEvent needs to retain.
+var scalingRatioWindows = []scalingRatioWindow{
+	{label: "15m", duration: 15 * time.Minute},
+	{label: "1h", duration: time.Hour},
+	{label: "24h", duration: 24 * time.Hour},
+}
+
+// NewReplicaTracker creates a new replica tracker, scoped to K8S_NAMESPACE
+// (default "stockfish") and the label selector in
+// K8S_TRACKED_LABEL_SELECTOR (default defaultLabelSelector).
 func NewReplicaTracker(metricsCol *metrics.MetricsCollector, logger logging.Logger) (*ReplicaTracker, error) {
 	// Create in-cluster config
 	config, err := rest.InClusterConfig()
@@ -51,47 +135,93 @@ func NewReplicaTracker(metricsCol *metrics.MetricsCollector, logger logging.Logg
 		logger.WithField("error", err.Error()).Warn("Not running in Kubernetes cluster, replica tracking disabled")
 		return nil, nil
 	}
-	
+
 	// Create clientset
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, err
 	}
-	
+
 	// Get namespace from environment or default to "stockfish"
 	namespace := os.Getenv("K8S_NAMESPACE")
 	if namespace == "" {
 		namespace = "stockfish"
 	}
-	
+
+	labelSelector := os.Getenv("K8S_TRACKED_LABEL_SELECTOR")
+	if labelSelector == "" {
+		labelSelector = defaultLabelSelector
+	}
+
+	factory := informers.NewSharedInformerFactoryWithOptions(
+		clientset,
+		resyncPeriod,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.LabelSelector = labelSelector
+		}),
+	)
+
 	rt := &ReplicaTracker{
-		clientset:         clientset,
-		namespace:         namespace,
-		metricsCol:        metricsCol,
-		logger:            logger,
-		stopChan:          make(chan struct{}),
-		replicaHistory:    make(map[string][]replicaSnapshot),
-		lastReplicaCounts: make(map[string]int32),
-		scaleUpCounts:     make(map[string]int64),
-		scaleDownCounts:   make(map[string]int64),
-	}
-	
+		clientset:          clientset,
+		namespace:          namespace,
+		labelSelector:      labelSelector,
+		metricsCol:         metricsCol,
+		logger:             logger,
+		factory:            factory,
+		deploymentInformer: factory.Apps().V1().Deployments(),
+		replicaSetInformer: factory.Apps().V1().ReplicaSets(),
+		hpaInformer:        factory.Autoscaling().V2().HorizontalPodAutoscalers(),
+		replicaHistory:     make(map[string][]replicaSnapshot),
+		lastReplicaCounts:  make(map[string]int32),
+		scalingEventLog:    make(map[string][]scalingEventRecord),
+		scalingCauses:      make(map[string]string),
+	}
+
+	// KEDA is optional: only wire up the ScaledObject watch if the CRD is
+	// actually registered in this cluster, so a deployment without KEDA
+	// installed doesn't spend the whole tracker lifetime logging watch
+	// errors against a resource that will never exist.
+	if _, err := clientset.Discovery().ServerResourcesForGroupVersion(scaledObjectGVR.GroupVersion().String()); err == nil {
+		dynamicClient, err := dynamic.NewForConfig(config)
+		if err != nil {
+			logger.WithField("error", err.Error()).Warn("KEDA CRD detected but failed to build dynamic client, ScaledObject tracking disabled")
+		} else {
+			rt.dynamicClient = dynamicClient
+			rt.dynamicFactory = dynamicinformer.NewFilteredDynamicSharedInformerFactory(
+				dynamicClient,
+				resyncPeriod,
+				namespace,
+				func(opts *metav1.ListOptions) {
+					opts.LabelSelector = labelSelector
+				},
+			)
+			rt.scaledObjectInformer = rt.dynamicFactory.ForResource(scaledObjectGVR).Informer()
+		}
+	} else {
+		logger.Info("KEDA ScaledObject CRD not found, HPA-only autoscaler tracking")
+	}
+
 	return rt, nil
 }
 
-// Start begins tracking replica counts
+// Start begins tracking replica counts in the background by running
+// RunWithContext in its own goroutine. It returns immediately and does not
+// wait for the informer caches' initial sync; RunWithContext logs once they
+// have synced.
 func (rt *ReplicaTracker) Start() {
 	if rt == nil {
 		return
 	}
-	
-	rt.logger.WithField("namespace", rt.namespace).Info("Starting replica tracker")
-	
-	// Track these deployments
-	deployments := []string{"api", "worker", "stockfish"}
-	
-	// Start tracking goroutine
-	go rt.trackReplicas(deployments)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rt.cancel = cancel
+
+	go func() {
+		if err := rt.RunWithContext(ctx); err != nil && ctx.Err() == nil {
+			rt.logger.WithField("error", err.Error()).Error("Replica tracker exited unexpectedly", err)
+		}
+	}()
 }
 
 // Stop stops the replica tracker
@@ -99,90 +229,316 @@ func (rt *ReplicaTracker) Stop() {
 	if rt == nil {
 		return
 	}
-	
-	close(rt.stopChan)
+
+	if rt.cancel != nil {
+		rt.cancel()
+	}
 	rt.logger.Info("Replica tracker stopped")
 }
 
-// trackReplicas periodically queries Kubernetes API for replica counts
-func (rt *ReplicaTracker) trackReplicas(deployments []string) {
-	// Update every 30 seconds
-	ticker := time.NewTicker(30 * time.Second)
-	defer ticker.Stop()
-	
-	// Initial update
-	rt.updateReplicaCounts(deployments)
-	
-	for {
-		select {
-		case <-rt.stopChan:
-			return
-		case <-ticker.C:
-			rt.updateReplicaCounts(deployments)
+// RunWithContext registers the deployment/replica-set informer event
+// handlers, starts the shared informer factory, waits for its initial
+// caches to sync, then blocks until ctx is cancelled - at which point the
+// factory's watches tear down deterministically instead of racing an
+// unbuffered stop channel against process exit.
+func (rt *ReplicaTracker) RunWithContext(ctx context.Context) error {
+	rt.logger.WithField("namespace", rt.namespace).Info("Starting replica tracker")
+
+	rt.registerHandlers()
+	rt.factory.Start(ctx.Done())
+
+	syncFuncs := []cache.InformerSynced{rt.deploymentInformer.Informer().HasSynced, rt.replicaSetInformer.Informer().HasSynced, rt.hpaInformer.Informer().HasSynced}
+
+	if rt.scaledObjectInformer != nil {
+		rt.dynamicFactory.Start(ctx.Done())
+		syncFuncs = append(syncFuncs, rt.scaledObjectInformer.HasSynced)
+	}
+
+	rt.logger.Info("Waiting for replica tracker informer caches to sync")
+	if !cache.WaitForCacheSync(ctx.Done(), syncFuncs...) {
+		return fmt.Errorf("timed out waiting for replica tracker informer caches to sync")
+	}
+	rt.logger.WithField("label_selector", rt.labelSelector).Info("Replica tracker watching for scaling events")
+
+	<-ctx.Done()
+	rt.logger.Info("Replica tracker shutting down")
+	return ctx.Err()
+}
+
+// registerHandlers wires the deployment informer's own Add/Update events,
+// and every owned ReplicaSet's Add/Update events, to observeDeployment -
+// a ReplicaSet changes the moment a rollout starts, often before the owning
+// Deployment's Status catches up, so watching both catches a scale-up/down
+// as early as the API server reports either one.
+func (rt *ReplicaTracker) registerHandlers() {
+	rt.deploymentInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if d, ok := obj.(*appsv1.Deployment); ok {
+				rt.observeDeployment(d)
+			}
+		},
+		UpdateFunc: func(_, newObj interface{}) {
+			if d, ok := newObj.(*appsv1.Deployment); ok {
+				rt.observeDeployment(d)
+			}
+		},
+	})
+
+	rt.replicaSetInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: rt.handleReplicaSetEvent,
+		UpdateFunc: func(_, newObj interface{}) {
+			rt.handleReplicaSetEvent(newObj)
+		},
+	})
+
+	rt.hpaInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if hpa, ok := obj.(*autoscalingv2.HorizontalPodAutoscaler); ok {
+				rt.observeHPA(hpa)
+			}
+		},
+		UpdateFunc: func(_, newObj interface{}) {
+			if hpa, ok := newObj.(*autoscalingv2.HorizontalPodAutoscaler); ok {
+				rt.observeHPA(hpa)
+			}
+		},
+	})
+
+	if rt.scaledObjectInformer != nil {
+		rt.scaledObjectInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				if u, ok := obj.(*unstructured.Unstructured); ok {
+					rt.observeScaledObject(u)
+				}
+			},
+			UpdateFunc: func(_, newObj interface{}) {
+				if u, ok := newObj.(*unstructured.Unstructured); ok {
+					rt.observeScaledObject(u)
+				}
+			},
+		})
+	}
+}
+
+// observeHPA updates the hpa_* gauges and the deployment's cached scaling
+// cause from a freshly observed HorizontalPodAutoscaler. The HPA's
+// spec.scaleTargetRef.name is assumed to be the tracked Deployment's name,
+// matching the convention every tracked deployment's HPA already follows.
+func (rt *ReplicaTracker) observeHPA(hpa *autoscalingv2.HorizontalPodAutoscaler) {
+	service := hpa.Spec.ScaleTargetRef.Name
+
+	minReplicas := int32(1)
+	if hpa.Spec.MinReplicas != nil {
+		minReplicas = *hpa.Spec.MinReplicas
+	}
+
+	rt.metricsCol.SetHPAReplicas(service, hpa.Name,
+		float64(hpa.Status.CurrentReplicas),
+		float64(hpa.Status.DesiredReplicas),
+		float64(minReplicas),
+		float64(hpa.Spec.MaxReplicas),
+	)
+
+	for _, m := range hpa.Status.CurrentMetrics {
+		metricName, value, ok := hpaMetricValue(m)
+		if ok {
+			rt.metricsCol.SetHPATargetUtilization(service, hpa.Name, metricName, value)
 		}
 	}
+
+	var reason string
+	for _, cond := range hpa.Status.Conditions {
+		rt.metricsCol.SetHPACondition(service, hpa.Name, string(cond.Type), string(cond.Status), cond.Status == corev1.ConditionTrue)
+
+		switch {
+		case cond.Type == autoscalingv2.ScalingLimited && cond.Status == corev1.ConditionTrue:
+			reason = cond.Reason
+		case reason == "" && cond.Type == autoscalingv2.ScalingActive:
+			reason = cond.Reason
+		}
+	}
+	rt.setScalingCause(service, reason)
+
+	rt.logger.WithFields(map[string]interface{}{
+		"hpa":     hpa.Name,
+		"service": service,
+		"current": hpa.Status.CurrentReplicas,
+		"desired": hpa.Status.DesiredReplicas,
+		"reason":  reason,
+	}).Debug("Updated HPA metrics")
 }
 
-// updateReplicaCounts queries Kubernetes API and updates metrics
-func (rt *ReplicaTracker) updateReplicaCounts(deployments []string) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	
-	for _, deploymentName := range deployments {
-		// Get deployment
-		deployment, err := rt.clientset.AppsV1().Deployments(rt.namespace).Get(ctx, deploymentName, metav1.GetOptions{})
-		if err != nil {
-			rt.logger.WithFields(map[string]interface{}{
-				"deployment": deploymentName,
-				"error":      err.Error(),
-			}).Warn("Failed to get deployment replica count")
+// hpaMetricValue extracts a PromQL-friendly metric name and its current
+// utilization/value from one entry of hpa.Status.CurrentMetrics, covering
+// the Resource (CPU/memory %), Pods, and External metric source types KEDA
+// and plain HPAs both use. ok is false for a metric type this doesn't
+// recognize.
+func hpaMetricValue(m autoscalingv2.MetricStatus) (name string, value float64, ok bool) {
+	switch m.Type {
+	case autoscalingv2.ResourceMetricSourceType:
+		if m.Resource == nil {
+			return "", 0, false
+		}
+		if m.Resource.Current.AverageUtilization != nil {
+			return string(m.Resource.Name), float64(*m.Resource.Current.AverageUtilization), true
+		}
+		if m.Resource.Current.AverageValue != nil {
+			return string(m.Resource.Name), m.Resource.Current.AverageValue.AsApproximateFloat64(), true
+		}
+	case autoscalingv2.PodsMetricSourceType:
+		if m.Pods == nil {
+			return "", 0, false
+		}
+		return m.Pods.Metric.Name, m.Pods.Current.AverageValue.AsApproximateFloat64(), true
+	case autoscalingv2.ExternalMetricSourceType:
+		if m.External == nil {
+			return "", 0, false
+		}
+		if m.External.Current.AverageValue != nil {
+			return m.External.Metric.Name, m.External.Current.AverageValue.AsApproximateFloat64(), true
+		}
+		if m.External.Current.Value != nil {
+			return m.External.Metric.Name, m.External.Current.Value.AsApproximateFloat64(), true
+		}
+	}
+	return "", 0, false
+}
+
+// observeScaledObject updates the same hpa_* gauges from a KEDA
+// ScaledObject's unstructured status, so autoscaler-aware tuning works
+// identically whether a tracked deployment is scaled by a plain HPA or a
+// KEDA ScaledObject (which manages its own HPA under the hood, but exposes
+// richer trigger-level status on the ScaledObject itself).
+func (rt *ReplicaTracker) observeScaledObject(u *unstructured.Unstructured) {
+	service, _, _ := unstructured.NestedString(u.Object, "spec", "scaleTargetRef", "name")
+	if service == "" {
+		return
+	}
+
+	current, _, _ := unstructured.NestedInt64(u.Object, "status", "currentReplicas")
+	desired, _, _ := unstructured.NestedInt64(u.Object, "status", "desiredReplicas")
+	minReplicas, hasMin, _ := unstructured.NestedInt64(u.Object, "spec", "minReplicaCount")
+	if !hasMin {
+		minReplicas = 0
+	}
+	maxReplicas, _, _ := unstructured.NestedInt64(u.Object, "spec", "maxReplicaCount")
+
+	rt.metricsCol.SetHPAReplicas(service, u.GetName(), float64(current), float64(desired), float64(minReplicas), float64(maxReplicas))
+
+	var reason string
+	conditions, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok {
 			continue
 		}
-		
-		// Get current replica count
-		replicas := int32(0)
-		if deployment.Spec.Replicas != nil {
-			replicas = *deployment.Spec.Replicas
+		condType, _, _ := unstructured.NestedString(cond, "type")
+		status, _, _ := unstructured.NestedString(cond, "status")
+		condReason, _, _ := unstructured.NestedString(cond, "reason")
+		rt.metricsCol.SetHPACondition(service, u.GetName(), condType, status, status == string(corev1.ConditionTrue))
+		if condType == "Active" {
+			reason = condReason
+		}
+	}
+	rt.setScalingCause(service, reason)
+
+	rt.logger.WithFields(map[string]interface{}{
+		"scaled_object": u.GetName(),
+		"service":       service,
+		"current":       current,
+		"desired":       desired,
+	}).Debug("Updated KEDA ScaledObject metrics")
+}
+
+// setScalingCause caches reason as the most recent autoscaler condition
+// reason for service, for detectScalingEvent to annotate its next scaling
+// event with. A blank reason is still stored (clearing a stale one) since an
+// HPA/ScaledObject with no matching condition yet genuinely has no cause to
+// report.
+func (rt *ReplicaTracker) setScalingCause(service, reason string) {
+	rt.causesMu.Lock()
+	rt.scalingCauses[service] = reason
+	rt.causesMu.Unlock()
+}
+
+// handleReplicaSetEvent resolves obj's owning Deployment (by name, via its
+// OwnerReferences) and re-observes it from the deployment informer's
+// lister cache, so a ReplicaSet-level event still drives the same replica
+// count/scaling-event logic as a Deployment-level one.
+func (rt *ReplicaTracker) handleReplicaSetEvent(obj interface{}) {
+	rs, ok := obj.(*appsv1.ReplicaSet)
+	if !ok {
+		return
+	}
+
+	deploymentName := ownerDeploymentName(rs)
+	if deploymentName == "" {
+		return
+	}
+
+	deployment, err := rt.deploymentInformer.Lister().Deployments(rt.namespace).Get(deploymentName)
+	if err != nil {
+		return
+	}
+	rt.observeDeployment(deployment)
+}
+
+// ownerDeploymentName returns rs's owning Deployment's name, or "" if it
+// isn't owned by one (e.g. a manually created ReplicaSet).
+func ownerDeploymentName(rs *appsv1.ReplicaSet) string {
+	for _, ref := range rs.OwnerReferences {
+		if ref.Kind == "Deployment" {
+			return ref.Name
 		}
-		
-		// Update current replica count metric (Requirement 5.4)
-		rt.metricsCol.SetReplicaCount(deploymentName, float64(replicas))
-		
-		// Detect and track scaling events (Requirement 5.8)
-		rt.detectScalingEvent(deploymentName, replicas)
-		
-		// Add to history
-		rt.addToHistory(deploymentName, replicas)
-		
-		// Calculate and update average replicas over 1-hour window (Requirement 5.4)
-		avgReplicas := rt.calculateAverageReplicas(deploymentName, time.Hour)
-		rt.metricsCol.SetAverageReplicas(deploymentName, avgReplicas)
-		
-		rt.logger.WithFields(map[string]interface{}{
-			"deployment":     deploymentName,
-			"replicas":       replicas,
-			"avg_1h":         avgReplicas,
-		}).Debug("Updated replica metrics")
 	}
+	return ""
+}
+
+// observeDeployment updates d's replica count metric, scaling event
+// counters, and rolling history from a freshly observed Deployment object.
+// Requirement 5.4: Query Kubernetes for current replica counts and calculate average replicas over 1-hour windows
+func (rt *ReplicaTracker) observeDeployment(d *appsv1.Deployment) {
+	name := d.Name
+	replicas := d.Status.Replicas
+
+	// Update current replica count metric (Requirement 5.4)
+	rt.metricsCol.SetReplicaCount(name, float64(replicas))
+
+	// Detect and track scaling events (Requirement 5.8)
+	rt.detectScalingEvent(name, replicas)
+
+	// Add to history
+	rt.addToHistory(name, replicas)
+
+	// Calculate and update average replicas over 1-hour window (Requirement 5.4)
+	avgReplicas := rt.calculateAverageReplicas(name, time.Hour)
+	rt.metricsCol.SetAverageReplicas(name, avgReplicas)
+
+	rt.logger.WithFields(map[string]interface{}{
+		"deployment":     name,
+		"replicas":       replicas,
+		"ready_replicas": d.Status.ReadyReplicas,
+		"avg_1h":         avgReplicas,
+	}).Debug("Updated replica metrics")
 }
 
 // addToHistory adds a replica count snapshot to history
 func (rt *ReplicaTracker) addToHistory(deployment string, count int32) {
 	rt.historyMu.Lock()
 	defer rt.historyMu.Unlock()
-	
+
 	snapshot := replicaSnapshot{
 		timestamp: time.Now(),
 		count:     count,
 	}
-	
+
 	// Add to history
 	rt.replicaHistory[deployment] = append(rt.replicaHistory[deployment], snapshot)
-	
+
 	// Clean up old entries (keep 2 hours of history)
 	cutoff := time.Now().Add(-2 * time.Hour)
 	history := rt.replicaHistory[deployment]
-	
+
 	// Find first index to keep
 	keepFrom := 0
 	for i, snap := range history {
@@ -191,7 +547,7 @@ func (rt *ReplicaTracker) addToHistory(deployment string, count int32) {
 			break
 		}
 	}
-	
+
 	// Trim old entries
 	if keepFrom > 0 {
 		rt.replicaHistory[deployment] = history[keepFrom:]
@@ -202,23 +558,23 @@ func (rt *ReplicaTracker) addToHistory(deployment string, count int32) {
 func (rt *ReplicaTracker) calculateAverageReplicas(deployment string, window time.Duration) float64 {
 	rt.historyMu.RLock()
 	defer rt.historyMu.RUnlock()
-	
+
 	history := rt.replicaHistory[deployment]
 	if len(history) == 0 {
 		return 0
 	}
-	
+
 	cutoff := time.Now().Add(-window)
-	
+
 	// Calculate weighted average based on time
 	var totalWeightedCount float64
 	var totalDuration float64
-	
+
 	for i := 0; i < len(history); i++ {
 		if history[i].timestamp.Before(cutoff) {
 			continue
 		}
-		
+
 		// Calculate duration this count was active
 		var duration time.Duration
 		if i < len(history)-1 {
@@ -226,11 +582,11 @@ func (rt *ReplicaTracker) calculateAverageReplicas(deployment string, window tim
 		} else {
 			duration = time.Since(history[i].timestamp)
 		}
-		
+
 		totalWeightedCount += float64(history[i].count) * duration.Seconds()
 		totalDuration += duration.Seconds()
 	}
-	
+
 	if totalDuration == 0 {
 		// Return current count if no history
 		if len(history) > 0 {
@@ -238,7 +594,7 @@ func (rt *ReplicaTracker) calculateAverageReplicas(deployment string, window tim
 		}
 		return 0
 	}
-	
+
 	return totalWeightedCount / totalDuration
 }
 
@@ -247,87 +603,149 @@ func (rt *ReplicaTracker) calculateAverageReplicas(deployment string, window tim
 func (rt *ReplicaTracker) detectScalingEvent(deployment string, currentCount int32) {
 	rt.lastCountsMu.Lock()
 	lastCount, exists := rt.lastReplicaCounts[deployment]
-	
+
 	// Update last known count
 	rt.lastReplicaCounts[deployment] = currentCount
 	rt.lastCountsMu.Unlock()
-	
+
 	// Skip if this is the first observation
 	if !exists {
 		return
 	}
-	
+
 	// Detect scaling event
 	if currentCount > lastCount {
-		// Scale-up event
 		rt.metricsCol.IncrementScalingEvents(deployment, "up")
-		
-		// Update internal counters for ratio calculation
-		rt.scalingCountsMu.Lock()
-		rt.scaleUpCounts[deployment]++
-		rt.scalingCountsMu.Unlock()
-		
+		rt.recordScalingEvent(deployment, "up")
+
 		rt.logger.WithFields(map[string]interface{}{
 			"deployment": deployment,
 			"from":       lastCount,
 			"to":         currentCount,
 			"direction":  "up",
+			"cause":      rt.scalingCause(deployment),
 		}).Info("Scaling event detected")
-		
-		// Update ratio metric
+
+		rt.recordScalingCause(deployment)
 		rt.updateScalingRatio(deployment)
-		
+
 	} else if currentCount < lastCount {
-		// Scale-down event
 		rt.metricsCol.IncrementScalingEvents(deployment, "down")
-		
-		// Update internal counters for ratio calculation
-		rt.scalingCountsMu.Lock()
-		rt.scaleDownCounts[deployment]++
-		rt.scalingCountsMu.Unlock()
-		
+		rt.recordScalingEvent(deployment, "down")
+
 		rt.logger.WithFields(map[string]interface{}{
 			"deployment": deployment,
 			"from":       lastCount,
 			"to":         currentCount,
 			"direction":  "down",
+			"cause":      rt.scalingCause(deployment),
 		}).Info("Scaling event detected")
-		
-		// Update ratio metric
+
+		rt.recordScalingCause(deployment)
 		rt.updateScalingRatio(deployment)
 	}
 }
 
-// updateScalingRatio calculates and updates the scaling events ratio metric
+// scalingRatioMaxWindow is the largest window in scalingRatioWindows,
+// assumed to be the last entry - recordScalingEvent only needs to retain
+// history out to this long.
+var scalingRatioMaxWindow = scalingRatioWindows[len(scalingRatioWindows)-1].duration
+
+// recordScalingEvent appends a scale event for deployment to its ring
+// buffer and evicts anything older than scalingRatioMaxWindow, so the
+// buffer's memory is bounded regardless of how long this deployment has
+// been tracked.
+func (rt *ReplicaTracker) recordScalingEvent(deployment, direction string) {
+	now := time.Now()
+
+	rt.scalingEventLogMu.Lock()
+	defer rt.scalingEventLogMu.Unlock()
+
+	events := append(rt.scalingEventLog[deployment], scalingEventRecord{timestamp: now, direction: direction})
+
+	cutoff := now.Add(-scalingRatioMaxWindow)
+	keepFrom := 0
+	for i, e := range events {
+		if e.timestamp.After(cutoff) {
+			keepFrom = i
+			break
+		}
+	}
+	if keepFrom > 0 {
+		events = events[keepFrom:]
+	}
+	rt.scalingEventLog[deployment] = events
+}
+
+// scalingCause returns the most recently observed autoscaler condition
+// reason cached for deployment by observeHPA/observeScaledObject, or "" if
+// none has been seen yet (e.g. this deployment isn't autoscaled by HPA or
+// KEDA at all).
+func (rt *ReplicaTracker) scalingCause(deployment string) string {
+	rt.causesMu.RLock()
+	defer rt.causesMu.RUnlock()
+	return rt.scalingCauses[deployment]
+}
+
+// recordScalingCause increments scaling_event_cause_total for deployment's
+// cached cause, so the raw scale-up/down ratio can be interpreted (e.g.
+// distinguishing "hit maxReplicas" from "genuine load drop").
+func (rt *ReplicaTracker) recordScalingCause(deployment string) {
+	reason := rt.scalingCause(deployment)
+	if reason == "" {
+		return
+	}
+	rt.metricsCol.IncrementScalingEventCause(deployment, reason)
+}
+
+// updateScalingRatio recalculates and publishes deployment's scale-up to
+// scale-down ratio over every window in scalingRatioWindows.
 // Requirement 5.8: Calculate ratio of scale-up events to scale-down events for tuning analysis
 func (rt *ReplicaTracker) updateScalingRatio(deployment string) {
-	rt.scalingCountsMu.RLock()
-	scaleUpCount := rt.scaleUpCounts[deployment]
-	scaleDownCount := rt.scaleDownCounts[deployment]
-	rt.scalingCountsMu.RUnlock()
-	
-	// Calculate ratio
-	var ratio float64
-	if scaleDownCount == 0 {
-		// If no scale-down events, ratio is infinite (represented as scale-up count)
-		// This indicates the system is only scaling up, never down
-		if scaleUpCount > 0 {
-			ratio = float64(scaleUpCount)
-		} else {
-			ratio = 0
+	rt.scalingEventLogMu.RLock()
+	events := append([]scalingEventRecord(nil), rt.scalingEventLog[deployment]...)
+	rt.scalingEventLogMu.RUnlock()
+
+	now := time.Now()
+	for _, w := range scalingRatioWindows {
+		cutoff := now.Add(-w.duration)
+
+		var upCount, downCount int64
+		for _, e := range events {
+			if e.timestamp.Before(cutoff) {
+				continue
+			}
+			if e.direction == "up" {
+				upCount++
+			} else {
+				downCount++
+			}
 		}
-	} else {
-		// Normal case: ratio of scale-up to scale-down
-		ratio = float64(scaleUpCount) / float64(scaleDownCount)
+
+		if downCount == 0 {
+			// No scale-downs in this window: leave the ratio gauge unset
+			// rather than publish the old "ratio = scale-up count" hack, so
+			// a PromQL alert can distinguish "never scaled down" from a
+			// genuine numeric imbalance.
+			rt.metricsCol.SetScalingEventsNoDownscale(deployment, w.label, upCount > 0)
+			rt.logger.WithFields(map[string]interface{}{
+				"deployment":     deployment,
+				"window":         w.label,
+				"scale_up_count": upCount,
+			}).Debug("No scale-down events in window, ratio left unset")
+			continue
+		}
+
+		rt.metricsCol.SetScalingEventsNoDownscale(deployment, w.label, false)
+		ratio := float64(upCount) / float64(downCount)
+		rt.metricsCol.SetScalingEventsRatio(deployment, w.label, ratio)
+
+		rt.logger.WithFields(map[string]interface{}{
+			"deployment":       deployment,
+			"window":           w.label,
+			"scale_up_count":   upCount,
+			"scale_down_count": downCount,
+			"ratio":            ratio,
+		}).Debug("Updated scaling events ratio")
 	}
-	
-	// Update metric
-	rt.metricsCol.SetScalingEventsRatio(deployment, ratio)
-	
-	rt.logger.WithFields(map[string]interface{}{
-		"deployment":       deployment,
-		"scale_up_count":   scaleUpCount,
-		"scale_down_count": scaleDownCount,
-		"ratio":            ratio,
-	}).Debug("Updated scaling events ratio")
 }
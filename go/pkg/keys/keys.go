@@ -0,0 +1,154 @@
+// Package keys builds every Redis key and channel name the api and worker
+// services use for the job queue, job lifecycle state, and per-job control
+// signals. All key construction lives here so the two services can never
+// drift apart on naming, and so every key family is scoped behind a Redis
+// Cluster hash tag.
+//
+// Invariant: any multi-key Lua script or MULTI/EXEC must only touch keys
+// that share one {stockfish:sN} tag, or Redis Cluster will reject it with
+// CROSSSLOT. Job(jobID), ResultKey(jobID), JobsQueue of that same shard,
+// and ActiveSet(ShardForJobID(jobID)) all share shard N's tag for exactly
+// this reason; do not introduce a key that mixes tags in one atomic
+// operation. ResultsNotifyChannel is the deliberate exception - pub/sub
+// isn't slot-routed, so it's a single global channel rather than per-shard.
+package keys
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+)
+
+// VirtualShards is the number of virtual shards the job queue, results list,
+// and active-job index are partitioned across. A Redis Cluster can place
+// each shard's key family on a different node; a single-node Redis just
+// holds all of them.
+const VirtualShards = 16
+
+// ShardForJobID deterministically maps jobID to a virtual shard in
+// [0, VirtualShards), so the api producer and worker consumer agree on which
+// shard's key family a given job belongs to without coordinating out of band.
+func ShardForJobID(jobID string) int {
+	h := fnv.New32a()
+	h.Write([]byte(jobID))
+	return int(h.Sum32() % uint32(VirtualShards))
+}
+
+// tag returns the hash-tag-scoped prefix for shard n, e.g. "{stockfish:s3}".
+// Every key built from the same tag is guaranteed to land on the same Redis
+// Cluster slot.
+func tag(shard int) string {
+	return fmt.Sprintf("{stockfish:s%d}", shard)
+}
+
+// JobsQueue returns the job queue stream key for shard.
+func JobsQueue(shard int) string {
+	return tag(shard) + ":jobs"
+}
+
+// AllJobsQueues returns every shard's job queue key, in shard order, for a
+// worker to read across via XREADGROUP so no shard's stream goes unserved.
+func AllJobsQueues() []string {
+	queues := make([]string, VirtualShards)
+	for i := range queues {
+		queues[i] = JobsQueue(i)
+	}
+	return queues
+}
+
+// JobsGroup is the consumer group name every worker joins via XREADGROUP on
+// each shard's job stream, so completed/abandoned deliveries are tracked per
+// consumer instead of being lost once popped like the old list-based queue.
+const JobsGroup = "stockfish"
+
+// Job returns the per-job lifecycle hash key. It always lands on the same
+// shard as ResultKey(jobID) and ActiveSet(ShardForJobID(jobID)), so a claim
+// or completion touching all three stays within one slot.
+func Job(jobID string) string {
+	return tag(ShardForJobID(jobID)) + ":job:" + jobID
+}
+
+// ActiveSet returns the active-job index set key for shard.
+func ActiveSet(shard int) string {
+	return tag(shard) + ":jobs:active"
+}
+
+// ResultKey returns the per-job result string key a worker SETs its
+// serialized JobResult to on completion, and an api waiter falls back to
+// GETing if it missed the ResultsNotifyChannel publish. It shares jobID's
+// shard tag with Job(jobID), so a worker can SET it in the same MULTI/EXEC
+// that finalizes the job hash.
+func ResultKey(jobID string) string {
+	return tag(ShardForJobID(jobID)) + ":result:" + jobID
+}
+
+// ResultsNotifyChannel is the single global pub/sub channel workers PUBLISH
+// a completed jobID to. Unlike ControlChannel, this is deliberately not
+// scoped per-shard: PUBLISH/SUBSCRIBE isn't slot-routed in Redis Cluster (it
+// fans out to every node), so one global channel costs nothing extra and
+// lets an api instance's single keywatcher subscription see every shard's
+// completions with one connection instead of VirtualShards of them.
+const ResultsNotifyChannel = "stockfish:results:notify"
+
+// AllShards returns the virtual shard indices [0, VirtualShards), for
+// callers that must fan out across every shard's active-job index (e.g. a
+// crash-recovery scan or a jobs-by-status tally).
+func AllShards() []int {
+	shards := make([]int, VirtualShards)
+	for i := range shards {
+		shards[i] = i
+	}
+	return shards
+}
+
+// controlChannelInfix separates a control channel's shard tag from the job
+// ID it carries cancellation signals for.
+const controlChannelInfix = ":ctl:"
+
+// ControlChannelPattern is the PSUBSCRIBE pattern matching every shard's
+// control channel, used by workers to receive a cancellation signal for any
+// job regardless of which shard its ID hashed to.
+const ControlChannelPattern = "{stockfish:s*}" + controlChannelInfix + "*"
+
+// ControlChannel returns the pub/sub channel the api publishes jobID's
+// cancellation signal on, and the worker holding jobID subscribes to.
+func ControlChannel(jobID string) string {
+	return tag(ShardForJobID(jobID)) + controlChannelInfix + jobID
+}
+
+// progressChannelInfix separates a progress channel's shard tag from the
+// job ID it carries incremental engine updates for.
+const progressChannelInfix = ":progress:"
+
+// ProgressChannel returns the pub/sub channel a worker PUBLISHes jobID's
+// incremental engine progress (info lines, then a final bestmove/error) to
+// while the job is in flight, and the api's SSE streaming handler
+// subscribes to for the life of one /move/stream connection.
+func ProgressChannel(jobID string) string {
+	return tag(ShardForJobID(jobID)) + progressChannelInfix + jobID
+}
+
+// MoveCachePrefix is the key prefix pkg/movecache GETs and SETEXes a
+// canonicalized (FEN, Elo, MoveTimeMs) digest under. Unlike the job-queue
+// families above, it carries no shard tag: it's never touched alongside
+// another key in the same MULTI/EXEC, so Cluster can place it on whichever
+// slot the digest happens to hash to.
+const MoveCachePrefix = "blunderbuss:move:"
+
+// MoveCacheKey returns the Redis key for a move cache entry keyed by
+// digest, the SHA-256 hex digest pkg/movecache computes from a request's
+// canonicalized (FEN, Elo, MoveTimeMs).
+func MoveCacheKey(digest string) string {
+	return MoveCachePrefix + digest
+}
+
+// JobIDFromControlChannel extracts the job ID from a channel name produced
+// by ControlChannel, the inverse a PSUBSCRIBE listener needs to look up
+// which locally-registered job a control message is for.
+func JobIDFromControlChannel(channel string) string {
+	idx := strings.Index(channel, controlChannelInfix)
+	if idx == -1 {
+		return ""
+	}
+	return channel[idx+len(controlChannelInfix):]
+}
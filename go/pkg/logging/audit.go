@@ -0,0 +1,128 @@
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+)
+
+// defaultMuxBufferSize is the per-writer channel capacity used when a
+// WriterConfig doesn't set BufferSize.
+const defaultMuxBufferSize = 256
+
+// AuditLogWriter is a sink for audit-quality log entries - stdout JSON, a
+// file rotator, Kafka, an HTTP webhook, an S3 batcher, or anything else
+// that wants its own delivery guarantees separate from the operational
+// slog.Handler a Logger normally writes through.
+type AuditLogWriter interface {
+	// WriteMessage persists entry. Writers that batch (Kafka, S3) should do
+	// so internally and only return an error for a hard failure.
+	WriteMessage(ctx context.Context, entry *LogEntry) error
+
+	// Start begins the writer's own processing goroutine, consuming
+	// MuxLogMessages off ch until it's closed. Called once by
+	// NewChanneledLogMux.
+	Start(ch chan MuxLogMessage)
+
+	// GetBufferSize reports the writer's current backlog, for metrics.
+	GetBufferSize() int
+}
+
+// MuxLogMessage is one entry handed to a writer's channel by ChanneledLogMux.
+type MuxLogMessage struct {
+	Ctx   context.Context
+	Entry *LogEntry
+}
+
+// WriterConfig registers one AuditLogWriter with a ChanneledLogMux.
+type WriterConfig struct {
+	Writer AuditLogWriter
+
+	// BufferSize is the writer's channel capacity. Defaults to
+	// defaultMuxBufferSize when <= 0.
+	BufferSize int
+
+	// DropOnFull makes Dispatch drop the entry for this writer instead of
+	// blocking the caller when its channel is saturated - appropriate for
+	// best-effort sinks (a webhook) but not for a tamper-evident audit
+	// trail that must not silently lose entries.
+	DropOnFull bool
+}
+
+// ChanneledLogMux fans a single log entry out to N registered
+// AuditLogWriters, each fed through its own buffered channel so a slow
+// writer can't stall the others or block the request handler that produced
+// the entry.
+type ChanneledLogMux struct {
+	writers []muxWriter
+}
+
+type muxWriter struct {
+	ch         chan MuxLogMessage
+	dropOnFull bool
+}
+
+// NewChanneledLogMux builds a mux from the given writer configs, starting
+// each writer's goroutine immediately.
+func NewChanneledLogMux(configs ...WriterConfig) *ChanneledLogMux {
+	mux := &ChanneledLogMux{writers: make([]muxWriter, 0, len(configs))}
+	for _, c := range configs {
+		bufSize := c.BufferSize
+		if bufSize <= 0 {
+			bufSize = defaultMuxBufferSize
+		}
+		ch := make(chan MuxLogMessage, bufSize)
+		c.Writer.Start(ch)
+		mux.writers = append(mux.writers, muxWriter{ch: ch, dropOnFull: c.DropOnFull})
+	}
+	return mux
+}
+
+// Dispatch fans entry out to every registered writer's channel. Writers
+// configured DropOnFull have the entry dropped rather than block the
+// caller when their channel is saturated; writers left at the default
+// (DropOnFull: false) do a plain blocking channel send instead, so a slow
+// or stuck one of those can block Dispatch - and therefore every logging
+// call site on a mux-backed Logger - indefinitely. That's intentional for a
+// writer that must not silently lose entries; set DropOnFull on any writer
+// where that tradeoff is wrong.
+func (m *ChanneledLogMux) Dispatch(ctx context.Context, entry *LogEntry) {
+	msg := MuxLogMessage{Ctx: ctx, Entry: entry}
+	for _, w := range m.writers {
+		if w.dropOnFull {
+			select {
+			case w.ch <- msg:
+			default:
+			}
+			continue
+		}
+		w.ch <- msg
+	}
+}
+
+// StdoutAuditWriter is a reference AuditLogWriter that marshals each entry
+// as a JSON line to stdout - a stand-in for the Kafka/S3/webhook writers a
+// real deployment would register instead.
+type StdoutAuditWriter struct{}
+
+// WriteMessage writes entry to stdout as a single JSON line.
+func (w *StdoutAuditWriter) WriteMessage(ctx context.Context, entry *LogEntry) error {
+	enc := json.NewEncoder(os.Stdout)
+	return enc.Encode(entry)
+}
+
+// Start consumes ch until it's closed, writing each message via
+// WriteMessage.
+func (w *StdoutAuditWriter) Start(ch chan MuxLogMessage) {
+	go func() {
+		for msg := range ch {
+			_ = w.WriteMessage(msg.Ctx, msg.Entry)
+		}
+	}()
+}
+
+// GetBufferSize always reports 0: StdoutAuditWriter writes synchronously as
+// messages arrive and holds nothing back.
+func (w *StdoutAuditWriter) GetBufferSize() int {
+	return 0
+}
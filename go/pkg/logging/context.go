@@ -0,0 +1,26 @@
+package logging
+
+import (
+	"context"
+
+	"stockfish-scale/pkg/correlation"
+)
+
+// contextFields extracts the correlation_id, operation_id, and external_id
+// propagated identifiers from ctx into the map shape WithFields already
+// accepts. This is the hook WithContext uses to auto-populate every log
+// entry with all three identifiers without callers remembering a separate
+// WithField call per identifier.
+func contextFields(ctx context.Context) map[string]interface{} {
+	fields := make(map[string]interface{}, 3)
+	if id, ok := correlation.FromContext(ctx); ok && id != "" {
+		fields["correlation_id"] = id
+	}
+	if id, ok := correlation.OperationIDFromContext(ctx); ok && id != "" {
+		fields["operation_id"] = id
+	}
+	if id, ok := correlation.ExternalIDFromContext(ctx); ok && id != "" {
+		fields["external_id"] = id
+	}
+	return fields
+}
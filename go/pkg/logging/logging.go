@@ -2,9 +2,9 @@ package logging
 
 import (
 	"context"
+	"log/slog"
+	"os"
 	"time"
-
-	"github.com/sirupsen/logrus"
 )
 
 // Logger interface for structured logging
@@ -12,6 +12,11 @@ type Logger interface {
 	WithCorrelationID(id string) Logger
 	WithFields(fields map[string]interface{}) Logger
 	WithField(key string, value interface{}) Logger
+	// WithContext returns a Logger with correlation_id, operation_id, and
+	// external_id populated from ctx (whichever of the three are present),
+	// so callers don't have to pull each one out of the pkg/correlation
+	// context and attach it with its own WithField call.
+	WithContext(ctx context.Context) Logger
 	Info(msg string)
 	Error(msg string, err error)
 	Warn(msg string)
@@ -29,10 +34,13 @@ type LogEntry struct {
 	Error         string                 `json:"error,omitempty"`
 }
 
-// StructuredLogger implements Logger interface using logrus
+// StructuredLogger implements Logger interface on top of log/slog. One
+// slog.Handler is bound at construction; SetLevel is the only method that
+// replaces it in place - WithField/WithFields/WithCorrelationID return a new StructuredLogger whose
+// handler already has those attributes baked in, the same way slog.Logger's
+// own With works.
 type StructuredLogger struct {
-	logger        *logrus.Logger
-	entry         *logrus.Entry
+	handler       slog.Handler
 	serviceName   string
 	correlationID string
 }
@@ -40,29 +48,41 @@ type StructuredLogger struct {
 // NewLogger creates a new structured logger with JSON formatting
 // Requirement 8.7: Use JSON format for all log entries
 func NewLogger(serviceName string) Logger {
-	logger := logrus.New()
-	logger.SetFormatter(&logrus.JSONFormatter{
-		TimestampFormat: time.RFC3339Nano,
-		FieldMap: logrus.FieldMap{
-			logrus.FieldKeyTime:  "timestamp",
-			logrus.FieldKeyLevel: "level",
-			logrus.FieldKeyMsg:   "message",
-		},
+	handler := slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		ReplaceAttr: renameToLogEntryKeys,
 	})
-	
+	return NewLoggerWithHandler(serviceName, handler)
+}
+
+// NewLoggerWithHandler creates a structured logger backed by an arbitrary
+// slog.Handler, so callers can plug in text, OTel, test-capture, or sampling
+// handlers instead of the default JSON-to-stdout one.
+func NewLoggerWithHandler(serviceName string, h slog.Handler) Logger {
 	return &StructuredLogger{
-		logger:      logger,
-		entry:       logger.WithField("service", serviceName),
+		handler:     h.WithAttrs([]slog.Attr{slog.String("service", serviceName)}),
 		serviceName: serviceName,
 	}
 }
 
+// renameToLogEntryKeys maps slog's default attribute keys onto the field
+// names LogEntry (and downstream log consumers) already expect.
+func renameToLogEntryKeys(groups []string, a slog.Attr) slog.Attr {
+	switch a.Key {
+	case slog.TimeKey:
+		a.Key = "timestamp"
+	case slog.MessageKey:
+		a.Key = "message"
+	case slog.LevelKey:
+		a.Key = "level"
+	}
+	return a
+}
+
 // WithCorrelationID returns a new logger with correlation ID
 // Requirements 8.1, 8.2, 8.3, 8.4, 8.6: Include correlation ID in all log entries
 func (l *StructuredLogger) WithCorrelationID(id string) Logger {
 	return &StructuredLogger{
-		logger:        l.logger,
-		entry:         l.entry.WithField("correlation_id", id),
+		handler:       l.handler.WithAttrs([]slog.Attr{slog.String("correlation_id", id)}),
 		serviceName:   l.serviceName,
 		correlationID: id,
 	}
@@ -70,9 +90,12 @@ func (l *StructuredLogger) WithCorrelationID(id string) Logger {
 
 // WithFields returns a new logger with additional fields
 func (l *StructuredLogger) WithFields(fields map[string]interface{}) Logger {
+	attrs := make([]slog.Attr, 0, len(fields))
+	for k, v := range fields {
+		attrs = append(attrs, slog.Any(k, v))
+	}
 	return &StructuredLogger{
-		logger:        l.logger,
-		entry:         l.entry.WithFields(fields),
+		handler:       l.handler.WithAttrs(attrs),
 		serviceName:   l.serviceName,
 		correlationID: l.correlationID,
 	}
@@ -81,52 +104,79 @@ func (l *StructuredLogger) WithFields(fields map[string]interface{}) Logger {
 // WithField returns a new logger with an additional field
 func (l *StructuredLogger) WithField(key string, value interface{}) Logger {
 	return &StructuredLogger{
-		logger:        l.logger,
-		entry:         l.entry.WithField(key, value),
+		handler:       l.handler.WithAttrs([]slog.Attr{slog.Any(key, value)}),
 		serviceName:   l.serviceName,
 		correlationID: l.correlationID,
 	}
 }
 
+// WithContext returns a new logger with correlation_id, operation_id, and
+// external_id populated from ctx
+func (l *StructuredLogger) WithContext(ctx context.Context) Logger {
+	return l.WithFields(contextFields(ctx))
+}
+
 // Info logs an info message
 func (l *StructuredLogger) Info(msg string) {
-	l.entry.Info(msg)
+	l.log(slog.LevelInfo, msg)
 }
 
 // Error logs an error message with error context
 // Requirement 8.6: Log all errors with correlation ID, timestamp, service name, and full error context
 func (l *StructuredLogger) Error(msg string, err error) {
 	if err != nil {
-		l.entry.WithField("error", err.Error()).Error(msg)
-	} else {
-		l.entry.Error(msg)
+		l.WithField("error", err.Error()).(*StructuredLogger).log(slog.LevelError, msg)
+		return
 	}
+	l.log(slog.LevelError, msg)
 }
 
 // Warn logs a warning message
 func (l *StructuredLogger) Warn(msg string) {
-	l.entry.Warn(msg)
+	l.log(slog.LevelWarn, msg)
 }
 
 // Debug logs a debug message
 func (l *StructuredLogger) Debug(msg string) {
-	l.entry.Debug(msg)
+	l.log(slog.LevelDebug, msg)
+}
+
+// log emits a record through the bound handler if it's enabled for level,
+// matching how slog.Logger itself skips disabled levels before building a
+// Record.
+func (l *StructuredLogger) log(level slog.Level, msg string) {
+	ctx := context.Background()
+	if !l.handler.Enabled(ctx, level) {
+		return
+	}
+	r := slog.NewRecord(time.Now(), level, msg, 0)
+	_ = l.handler.Handle(ctx, r)
 }
 
-// SetLevel sets the logging level
+// SetLevel sets the logging level by swapping in a level-filtered handler
+// wrapping the default JSON-to-stdout handler. It replaces whatever handler
+// l was built with, including a custom one passed to NewLoggerWithHandler,
+// and the new handler carries only the service attribute: attributes added
+// through WithField, WithFields or WithCorrelationID are not kept.
 func (l *StructuredLogger) SetLevel(level string) {
+	var slogLevel slog.Level
 	switch level {
 	case "debug":
-		l.logger.SetLevel(logrus.DebugLevel)
+		slogLevel = slog.LevelDebug
 	case "info":
-		l.logger.SetLevel(logrus.InfoLevel)
+		slogLevel = slog.LevelInfo
 	case "warn":
-		l.logger.SetLevel(logrus.WarnLevel)
+		slogLevel = slog.LevelWarn
 	case "error":
-		l.logger.SetLevel(logrus.ErrorLevel)
+		slogLevel = slog.LevelError
 	default:
-		l.logger.SetLevel(logrus.InfoLevel)
+		slogLevel = slog.LevelInfo
 	}
+
+	l.handler = slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		Level:       slogLevel,
+		ReplaceAttr: renameToLogEntryKeys,
+	}).WithAttrs([]slog.Attr{slog.String("service", l.serviceName)})
 }
 
 // contextKey is a custom type for context keys to avoid collisions
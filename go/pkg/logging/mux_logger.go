@@ -0,0 +1,112 @@
+package logging
+
+import (
+	"context"
+	"time"
+)
+
+// muxLogger wraps a StructuredLogger, additionally fanning every entry out
+// - always including correlation ID, service, and timestamp - to a
+// ChanneledLogMux, so operational logs (via the wrapped slog.Handler) stay
+// separate from a tamper-evident audit trail (via the mux's writers).
+type muxLogger struct {
+	inner         Logger
+	mux           *ChanneledLogMux
+	serviceName   string
+	correlationID string
+	fields        map[string]interface{}
+}
+
+// NewLoggerWithMux returns a Logger that behaves like NewLogger but also
+// dispatches every entry to mux's registered AuditLogWriters. Dispatch never
+// blocks on a writer's own I/O - each writer consumes off its own buffered
+// channel - but a writer can still make Info/Error/Warn/Debug block the
+// caller if its channel fills up: DropOnFull writers (WriterConfig) drop the
+// entry instead, but the default (DropOnFull: false) is a plain channel
+// send with no timeout, by design, so a tamper-evident audit trail writer
+// never silently loses an entry. Register slow or unreliable sinks
+// (webhooks, batched uploaders) with DropOnFull: true if blocking every
+// logging call site on them is unacceptable.
+func NewLoggerWithMux(serviceName string, mux *ChanneledLogMux) Logger {
+	return &muxLogger{
+		inner:       NewLogger(serviceName),
+		mux:         mux,
+		serviceName: serviceName,
+	}
+}
+
+func (l *muxLogger) WithCorrelationID(id string) Logger {
+	return &muxLogger{
+		inner:         l.inner.WithCorrelationID(id),
+		mux:           l.mux,
+		serviceName:   l.serviceName,
+		correlationID: id,
+		fields:        l.fields,
+	}
+}
+
+func (l *muxLogger) WithFields(fields map[string]interface{}) Logger {
+	merged := make(map[string]interface{}, len(l.fields)+len(fields))
+	for k, v := range l.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return &muxLogger{
+		inner:         l.inner.WithFields(fields),
+		mux:           l.mux,
+		serviceName:   l.serviceName,
+		correlationID: l.correlationID,
+		fields:        merged,
+	}
+}
+
+func (l *muxLogger) WithField(key string, value interface{}) Logger {
+	return l.WithFields(map[string]interface{}{key: value})
+}
+
+func (l *muxLogger) WithContext(ctx context.Context) Logger {
+	return l.WithFields(contextFields(ctx))
+}
+
+func (l *muxLogger) Info(msg string) {
+	l.inner.Info(msg)
+	l.dispatch("info", msg, "")
+}
+
+func (l *muxLogger) Error(msg string, err error) {
+	l.inner.Error(msg, err)
+	errStr := ""
+	if err != nil {
+		errStr = err.Error()
+	}
+	l.dispatch("error", msg, errStr)
+}
+
+func (l *muxLogger) Warn(msg string) {
+	l.inner.Warn(msg)
+	l.dispatch("warn", msg, "")
+}
+
+func (l *muxLogger) Debug(msg string) {
+	l.inner.Debug(msg)
+	l.dispatch("debug", msg, "")
+}
+
+// dispatch builds the LogEntry once and hands it to the mux, which fans the
+// same entry out to every registered writer.
+func (l *muxLogger) dispatch(level, msg, errStr string) {
+	if l.mux == nil {
+		return
+	}
+	l.mux.Dispatch(context.Background(), &LogEntry{
+		Timestamp:     time.Now(),
+		Level:         level,
+		Message:       msg,
+		CorrelationID: l.correlationID,
+		Service:       l.serviceName,
+		Fields:        l.fields,
+		Error:         errStr,
+	})
+}
@@ -0,0 +1,357 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// ExporterKind selects which metrics transport(s)
+// NewMetricsCollectorWithExporter wires up.
+type ExporterKind string
+
+const (
+	// ExporterPrometheus keeps today's behavior: instruments register to
+	// prometheus.DefaultRegisterer and /metrics (promhttp.Handler) scrapes
+	// them directly, so its Exporter is a no-op.
+	ExporterPrometheus ExporterKind = "prometheus"
+	// ExporterOTLP periodically converts every registered instrument into
+	// OTLP points and pushes them to Endpoint instead of waiting to be
+	// scraped.
+	ExporterOTLP ExporterKind = "otlp"
+	// ExporterBoth runs the Prometheus scrape endpoint and the OTLP pusher
+	// side by side off the same underlying instruments.
+	ExporterBoth ExporterKind = "both"
+)
+
+// ExporterConfig configures NewMetricsCollectorWithExporter's metrics
+// transport.
+type ExporterConfig struct {
+	// Kind selects the transport. The zero value behaves like
+	// ExporterPrometheus, so existing callers of NewMetricsCollector are
+	// unaffected.
+	Kind ExporterKind
+
+	// Endpoint is the OTLP collector's gRPC address (e.g.
+	// "otel-collector:4317"). Required when Kind is ExporterOTLP or
+	// ExporterBoth.
+	Endpoint string
+
+	// Interval is how often the OTLP pusher gathers and pushes. Defaults to
+	// 15s. Ignored when Kind is ExporterPrometheus.
+	Interval time.Duration
+
+	// Headers are extra gRPC metadata headers sent with every OTLP push
+	// (e.g. an API key a hosted collector expects).
+	Headers map[string]string
+}
+
+// ExporterConfigFromEnv reads METRICS_EXPORTER_KIND ("prometheus", "otlp", or
+// "both"; default "prometheus"), METRICS_OTLP_ENDPOINT, METRICS_OTLP_INTERVAL,
+// and METRICS_OTLP_HEADERS ("key1=value1,key2=value2").
+func ExporterConfigFromEnv() ExporterConfig {
+	cfg := ExporterConfig{
+		Kind:     ExporterKind(envOr("METRICS_EXPORTER_KIND", string(ExporterPrometheus))),
+		Endpoint: os.Getenv("METRICS_OTLP_ENDPOINT"),
+		Interval: 15 * time.Second,
+	}
+	if v := os.Getenv("METRICS_OTLP_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cfg.Interval = d
+		}
+	}
+	if v := os.Getenv("METRICS_OTLP_HEADERS"); v != "" {
+		cfg.Headers = make(map[string]string)
+		for _, pair := range strings.Split(v, ",") {
+			k, val, ok := strings.Cut(pair, "=")
+			if ok {
+				cfg.Headers[k] = val
+			}
+		}
+	}
+	return cfg
+}
+
+func envOr(k, def string) string {
+	if v := os.Getenv(k); v != "" {
+		return v
+	}
+	return def
+}
+
+// Exporter starts and stops a metrics transport bridging the Prometheus
+// registry promauto registers every MetricsCollector instrument into.
+type Exporter interface {
+	Start() error
+	Stop(ctx context.Context) error
+}
+
+// NewMetricsCollectorWithExporter builds a MetricsCollector the same way
+// NewMetricsCollector does, plus whichever Exporter cfg.Kind selects. The
+// returned Exporter's Start must be called before it begins pushing (a
+// ExporterPrometheus Exporter's Start/Stop are no-ops, since /metrics already
+// scrapes on demand), and Stop called during shutdown so the OTLP pusher
+// flushes its last interval's points before the process exits.
+func NewMetricsCollectorWithExporter(serviceName string, cfg ExporterConfig) (*MetricsCollector, Exporter, error) {
+	mc := NewMetricsCollector(serviceName)
+
+	switch cfg.Kind {
+	case "", ExporterPrometheus:
+		return mc, noopExporter{}, nil
+
+	case ExporterOTLP:
+		exp, err := newOTLPExporter(serviceName, cfg)
+		if err != nil {
+			return nil, nil, err
+		}
+		return mc, exp, nil
+
+	case ExporterBoth:
+		exp, err := newOTLPExporter(serviceName, cfg)
+		if err != nil {
+			return nil, nil, err
+		}
+		return mc, multiExporter{noopExporter{}, exp}, nil
+
+	default:
+		return nil, nil, fmt.Errorf("metrics: unknown exporter kind %q", cfg.Kind)
+	}
+}
+
+// noopExporter backs ExporterPrometheus: prometheus.DefaultGatherer is
+// already scraped directly by promhttp.Handler, so there's nothing to push.
+type noopExporter struct{}
+
+func (noopExporter) Start() error                   { return nil }
+func (noopExporter) Stop(ctx context.Context) error { return nil }
+
+// multiExporter runs every member's Start/Stop, for ExporterBoth.
+type multiExporter []Exporter
+
+func (m multiExporter) Start() error {
+	for _, e := range m {
+		if err := e.Start(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m multiExporter) Stop(ctx context.Context) error {
+	var firstErr error
+	for _, e := range m {
+		if err := e.Stop(ctx); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// otlpExporter periodically gathers every metric family promauto registered
+// to prometheus.DefaultGatherer, converts each to its OTLP equivalent, and
+// pushes them to an OTLP collector over gRPC - a bridge rather than a native
+// OTel SDK MeterProvider, since every instrument in this package is already
+// a promauto Histogram/Counter/Gauge.
+type otlpExporter struct {
+	exporter *otlpmetricgrpc.Exporter
+	resource *resource.Resource
+	interval time.Duration
+
+	stopChan    chan struct{}
+	stoppedChan chan struct{}
+}
+
+func newOTLPExporter(serviceName string, cfg ExporterConfig) (*otlpExporter, error) {
+	if cfg.Endpoint == "" {
+		return nil, fmt.Errorf("metrics: ExporterConfig.Endpoint is required for Kind %q", cfg.Kind)
+	}
+
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = 15 * time.Second
+	}
+
+	opts := []otlpmetricgrpc.Option{
+		otlpmetricgrpc.WithEndpoint(cfg.Endpoint),
+		otlpmetricgrpc.WithDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+	}
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlpmetricgrpc.WithHeaders(cfg.Headers))
+	}
+
+	exp, err := otlpmetricgrpc.New(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("metrics: building OTLP exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		semconv.ServiceNameKey.String(serviceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("metrics: building OTLP resource: %w", err)
+	}
+
+	return &otlpExporter{
+		exporter:    exp,
+		resource:    res,
+		interval:    interval,
+		stopChan:    make(chan struct{}),
+		stoppedChan: make(chan struct{}),
+	}, nil
+}
+
+func (e *otlpExporter) Start() error {
+	go e.run()
+	return nil
+}
+
+func (e *otlpExporter) Stop(ctx context.Context) error {
+	close(e.stopChan)
+	<-e.stoppedChan
+	return e.exporter.Shutdown(ctx)
+}
+
+func (e *otlpExporter) run() {
+	defer close(e.stoppedChan)
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			// Best-effort: a failed push is dropped rather than retried,
+			// since the next tick's gather already has a fresher value to
+			// push instead.
+			_ = e.push()
+		case <-e.stopChan:
+			return
+		}
+	}
+}
+
+func (e *otlpExporter) push() error {
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		return fmt.Errorf("metrics: gathering Prometheus families for OTLP push: %w", err)
+	}
+
+	now := time.Now()
+	scopeMetrics := metricdata.ScopeMetrics{
+		Metrics: make([]metricdata.Metrics, 0, len(families)),
+	}
+	for _, family := range families {
+		if m, ok := convertFamily(family, now); ok {
+			scopeMetrics.Metrics = append(scopeMetrics.Metrics, m)
+		}
+	}
+
+	return e.exporter.Export(context.Background(), &metricdata.ResourceMetrics{
+		Resource:     e.resource,
+		ScopeMetrics: []metricdata.ScopeMetrics{scopeMetrics},
+	})
+}
+
+// convertFamily maps one Prometheus MetricFamily to its OTLP equivalent:
+// COUNTER -> Sum{IsMonotonic: true, Temporality: Cumulative}, GAUGE -> Gauge,
+// HISTOGRAM -> an explicit-bucket Histogram preserving its bucket
+// boundaries. Summaries (the sliding-quantile SummaryVecs from
+// NewMetricsCollectorWithOptions) have no clean OTLP equivalent and are
+// skipped - their Prometheus-native scrape remains the way to read them.
+func convertFamily(family *dto.MetricFamily, ts time.Time) (metricdata.Metrics, bool) {
+	switch family.GetType() {
+	case dto.MetricType_COUNTER:
+		points := make([]metricdata.DataPoint[float64], 0, len(family.Metric))
+		for _, m := range family.Metric {
+			points = append(points, metricdata.DataPoint[float64]{
+				Attributes: attribute.NewSet(labelsToAttributes(m.GetLabel())...),
+				Time:       ts,
+				Value:      m.GetCounter().GetValue(),
+			})
+		}
+		return metricdata.Metrics{
+			Name: family.GetName(),
+			Data: metricdata.Sum[float64]{
+				DataPoints:  points,
+				Temporality: metricdata.CumulativeTemporality,
+				IsMonotonic: true,
+			},
+		}, true
+
+	case dto.MetricType_GAUGE:
+		points := make([]metricdata.DataPoint[float64], 0, len(family.Metric))
+		for _, m := range family.Metric {
+			points = append(points, metricdata.DataPoint[float64]{
+				Attributes: attribute.NewSet(labelsToAttributes(m.GetLabel())...),
+				Time:       ts,
+				Value:      m.GetGauge().GetValue(),
+			})
+		}
+		return metricdata.Metrics{
+			Name: family.GetName(),
+			Data: metricdata.Gauge[float64]{DataPoints: points},
+		}, true
+
+	case dto.MetricType_HISTOGRAM:
+		points := make([]metricdata.HistogramDataPoint[float64], 0, len(family.Metric))
+		for _, m := range family.Metric {
+			h := m.GetHistogram()
+			buckets := h.GetBucket()
+			bounds := make([]float64, 0, len(buckets))
+			counts := make([]uint64, 0, len(buckets)+1)
+			// Prometheus buckets are cumulative and include a terminal
+			// +Inf bound; OTLP's explicit-bucket Histogram wants
+			// per-bucket deltas and an implicit (omitted) +Inf bound.
+			var prevCumulative uint64
+			for _, b := range buckets {
+				if math.IsInf(b.GetUpperBound(), 1) {
+					continue
+				}
+				bounds = append(bounds, b.GetUpperBound())
+				counts = append(counts, b.GetCumulativeCount()-prevCumulative)
+				prevCumulative = b.GetCumulativeCount()
+			}
+			counts = append(counts, h.GetSampleCount()-prevCumulative)
+			points = append(points, metricdata.HistogramDataPoint[float64]{
+				Attributes:   attribute.NewSet(labelsToAttributes(m.GetLabel())...),
+				Time:         ts,
+				Count:        h.GetSampleCount(),
+				Sum:          h.GetSampleSum(),
+				Bounds:       bounds,
+				BucketCounts: counts,
+			})
+		}
+		return metricdata.Metrics{
+			Name: family.GetName(),
+			Data: metricdata.Histogram[float64]{
+				DataPoints:  points,
+				Temporality: metricdata.CumulativeTemporality,
+			},
+		}, true
+
+	default:
+		return metricdata.Metrics{}, false
+	}
+}
+
+func labelsToAttributes(labels []*dto.LabelPair) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(labels))
+	for _, l := range labels {
+		attrs = append(attrs, attribute.String(l.GetName(), l.GetValue()))
+	}
+	return attrs
+}
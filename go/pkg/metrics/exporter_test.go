@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestConvertFamilyHistogramUsesPerBucketDeltasAndDropsInfBound(t *testing.T) {
+	name := "queue_wait_time"
+	histType := dto.MetricType_HISTOGRAM
+	sampleCount := uint64(10)
+	sampleSum := 12.5
+
+	bound1, bound2, boundInf := 0.1, 0.5, math.Inf(1)
+	count1, count2, countInf := uint64(3), uint64(7), uint64(10)
+
+	family := &dto.MetricFamily{
+		Name: &name,
+		Type: &histType,
+		Metric: []*dto.Metric{
+			{
+				Histogram: &dto.Histogram{
+					SampleCount: &sampleCount,
+					SampleSum:   &sampleSum,
+					Bucket: []*dto.Bucket{
+						{UpperBound: &bound1, CumulativeCount: &count1},
+						{UpperBound: &bound2, CumulativeCount: &count2},
+						{UpperBound: &boundInf, CumulativeCount: &countInf},
+					},
+				},
+			},
+		},
+	}
+
+	metrics, ok := convertFamily(family, time.Now())
+	if !ok {
+		t.Fatalf("convertFamily returned ok=false for a histogram family")
+	}
+
+	hist, ok := metrics.Data.(metricdata.Histogram[float64])
+	if !ok {
+		t.Fatalf("expected metricdata.Histogram[float64], got %T", metrics.Data)
+	}
+	if len(hist.DataPoints) != 1 {
+		t.Fatalf("expected 1 data point, got %d", len(hist.DataPoints))
+	}
+
+	dp := hist.DataPoints[0]
+	wantBounds := []float64{0.1, 0.5}
+	if len(dp.Bounds) != len(wantBounds) {
+		t.Fatalf("Bounds = %v, want %v (the +Inf bound must be omitted)", dp.Bounds, wantBounds)
+	}
+	for i, b := range wantBounds {
+		if dp.Bounds[i] != b {
+			t.Errorf("Bounds[%d] = %v, want %v", i, dp.Bounds[i], b)
+		}
+	}
+
+	wantCounts := []uint64{3, 4, 3}
+	if len(dp.BucketCounts) != len(wantCounts) {
+		t.Fatalf("BucketCounts = %v, want %v", dp.BucketCounts, wantCounts)
+	}
+	for i, c := range wantCounts {
+		if dp.BucketCounts[i] != c {
+			t.Errorf("BucketCounts[%d] = %d, want %d (must be per-bucket, not cumulative)", i, dp.BucketCounts[i], c)
+		}
+	}
+
+	var sum uint64
+	for _, c := range dp.BucketCounts {
+		sum += c
+	}
+	if sum != dp.Count {
+		t.Errorf("BucketCounts sum = %d, want Count = %d", sum, dp.Count)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"sync"
 	"time"
 
+	dto "github.com/prometheus/client_model/go"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
@@ -14,7 +16,14 @@ type MetricsCollector struct {
 	requestDuration *prometheus.HistogramVec
 	requestCounter  *prometheus.CounterVec
 	queueDepth      prometheus.Gauge
-	
+
+	// Per-tenant cardinality protection (pkg/metrics/tenantstats)
+	tenantOverflow prometheus.Counter
+
+	// Pipelined job enqueue metrics (api only, REDIS_PIPE_PERIOD)
+	redisPipelineBatchSize     prometheus.Histogram
+	redisPipelineFlushDuration prometheus.Histogram
+
 	// Worker Service metrics
 	queueWaitTime        *prometheus.HistogramVec
 	engineConnectionTime *prometheus.HistogramVec
@@ -23,34 +32,156 @@ type MetricsCollector struct {
 	totalProcessingTime  *prometheus.HistogramVec
 	idleTime             prometheus.Counter
 	idlePercentage       prometheus.Gauge
+	idlePercentageWindow *prometheus.GaugeVec
 	activeJobs           prometheus.Gauge
-	
+	jobsByStatus         *prometheus.GaugeVec
+	enginePoolInUse      *prometheus.GaugeVec
+	enginePoolIdle       *prometheus.GaugeVec
+	enginePoolWaitTime   *prometheus.HistogramVec
+	enginePoolEvictions  *prometheus.CounterVec
+	resultBatchSize      prometheus.Histogram
+	resultFlushLatency   prometheus.Histogram
+
 	// Circuit breaker metrics
-	circuitState   *prometheus.GaugeVec
-	circuitFailures *prometheus.CounterVec
-	
+	circuitState        *prometheus.GaugeVec
+	circuitFailures     *prometheus.CounterVec
+	circuitAsyncQueue   *prometheus.GaugeVec
+	circuitAsyncDropped *prometheus.CounterVec
+
 	// Retry metrics
 	retryAttempts *prometheus.CounterVec
-	
+
+	// Redis connection topology metrics
+	redisFailovers *prometheus.CounterVec
+
+	// Job stream (XREADGROUP) metrics
+	streamPending     prometheus.Gauge
+	streamReclaimed   prometheus.Counter
+	streamConsumerLag prometheus.Gauge
+
+	// Move cache metrics
+	moveCacheHits *prometheus.CounterVec
+
+	// Periodic job scheduler metrics
+	schedulerDrift        prometheus.Histogram
+	schedulerSkippedFires prometheus.Counter
+
 	// Cost efficiency metrics
-	successfulOps      prometheus.Counter
-	cpuSeconds         prometheus.Counter
-	costEfficiency     prometheus.Gauge
-	replicaCount       *prometheus.GaugeVec
-	averageReplicas    *prometheus.GaugeVec
-	
+	successfulOps   *prometheus.CounterVec
+	cpuSeconds      *prometheus.CounterVec
+	costEfficiency  *prometheus.GaugeVec
+	replicaCount    *prometheus.GaugeVec
+	averageReplicas *prometheus.GaugeVec
+
+	// CPU time class breakdown (runtime/metrics /cpu/classes/... tree)
+	cpuSecondsUser     prometheus.Counter
+	cpuSecondsSystem   prometheus.Counter
+	cpuSecondsGCTotal  prometheus.Counter
+	cpuSecondsGCPause  prometheus.Counter
+	cpuSecondsIdle     prometheus.Counter
+	cpuSecondsScavenge prometheus.Counter
+
+	// Cgroup CPU throttling and PSI pressure
+	cpuThrottledSeconds prometheus.Counter
+	cpuThrottleEvents   prometheus.Counter
+	cpuPressureAvg10    prometheus.Gauge
+
 	// Queue metrics
 	queueDepthVariance prometheus.Gauge
-	
+
 	// Scaling metrics
-	scalingEvents      *prometheus.CounterVec
-	scalingEventsRatio *prometheus.GaugeVec
+	scalingEvents            *prometheus.CounterVec
+	scalingEventsRatio       *prometheus.GaugeVec
+	scalingEventsNoDownscale *prometheus.GaugeVec
+
+	// HPA/KEDA autoscaler state (pkg/k8s ReplicaTracker)
+	hpaCurrentReplicas   *prometheus.GaugeVec
+	hpaDesiredReplicas   *prometheus.GaugeVec
+	hpaMinReplicas       *prometheus.GaugeVec
+	hpaMaxReplicas       *prometheus.GaugeVec
+	hpaTargetUtilization *prometheus.GaugeVec
+	hpaCondition         *prometheus.GaugeVec
+	scalingEventCause    *prometheus.CounterVec
+
+	// Self-verification drift metrics (pkg/metrics/verifier)
+	metricsDrift *prometheus.CounterVec
+
+	// Audit log writer backlog (pkg/logging ChanneledLogMux/AuditLogWriter)
+	auditLogBufferSize *prometheus.GaugeVec
+
+	// Sliding-quantile summaries (opt-in via NewMetricsCollectorWithOptions'
+	// EnableSummaries), parallel to the fixed-bucket Histograms above for
+	// the same latency-critical series - quantile accuracy here isn't
+	// bounded by the Histograms' hand-picked bucket boundaries.
+	enableSummaries            bool
+	requestDurationSummary     *prometheus.SummaryVec
+	engineComputeTimeSummary   *prometheus.SummaryVec
+	totalProcessingTimeSummary *prometheus.SummaryVec
+
+	// LatencyTracker.PublishCheckpoints' per-prefix {prefix}_checkpoint_seconds
+	// histogram/summary pairs. Created lazily since prefix is caller-chosen,
+	// not known at construction time like the series above.
+	checkpointMu         sync.Mutex
+	checkpointHistograms map[string]*prometheus.HistogramVec
+	checkpointSummaries  map[string]*prometheus.SummaryVec
+}
+
+// Options configures optional MetricsCollector instrumentation beyond the
+// always-on Histograms/Counters/Gauges NewMetricsCollector registers.
+type Options struct {
+	// EnableSummaries additionally registers a SummaryVec with sliding
+	// quantile Objectives alongside each latency-critical HistogramVec
+	// (api_request_duration_seconds, worker_engine_computation_seconds,
+	// worker_total_processing_seconds), so P50/P90/P95/P99 reflect the last
+	// 10 minutes of behavior instead of being bounded by hand-picked bucket
+	// boundaries - particularly useful under bimodal load like Stockfish
+	// computation time.
+	EnableSummaries bool
+}
+
+// summaryObjectives is the quantile/error-tolerance map every opt-in
+// SummaryVec uses.
+var summaryObjectives = map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.95: 0.005, 0.99: 0.001}
+
+// summaryMaxAge and summaryAgeBuckets bound every opt-in SummaryVec's
+// decay window to the last 10 minutes, in 5 sub-buckets, so its quantiles
+// track recent behavior rather than a lifetime average.
+const (
+	summaryMaxAge     = 10 * time.Minute
+	summaryAgeBuckets = 5
+)
+
+// newLatencySummary builds a SummaryVec sharing every opt-in summary's
+// Objectives/MaxAge/AgeBuckets, varying only by name, help text, and labels.
+func newLatencySummary(name, help string, labelNames []string) *prometheus.SummaryVec {
+	return promauto.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Name:       name,
+			Help:       help,
+			Objectives: summaryObjectives,
+			MaxAge:     summaryMaxAge,
+			AgeBuckets: summaryAgeBuckets,
+		},
+		labelNames,
+	)
 }
 
-// NewMetricsCollector creates a new metrics collector for the specified service
+// NewMetricsCollector creates a new metrics collector for the specified
+// service, with every opt-in Options left at its default (summaries off).
 func NewMetricsCollector(serviceName string) *MetricsCollector {
-	mc := &MetricsCollector{}
-	
+	return NewMetricsCollectorWithOptions(serviceName, Options{})
+}
+
+// NewMetricsCollectorWithOptions creates a new metrics collector for the
+// specified service, additionally registering whichever opt-in
+// instrumentation opts enables.
+func NewMetricsCollectorWithOptions(serviceName string, opts Options) *MetricsCollector {
+	mc := &MetricsCollector{
+		enableSummaries:      opts.EnableSummaries,
+		checkpointHistograms: make(map[string]*prometheus.HistogramVec),
+		checkpointSummaries:  make(map[string]*prometheus.SummaryVec),
+	}
+
 	// API Service metrics (requirements 1.1, 1.5, 1.6, 1.8)
 	if serviceName == "api" {
 		mc.requestDuration = promauto.NewHistogramVec(
@@ -59,32 +190,64 @@ func NewMetricsCollector(serviceName string) *MetricsCollector {
 				Help:    "API request latency with percentiles (P50, P95, P99)",
 				Buckets: []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 2, 5, 10, 30},
 			},
-			[]string{"endpoint", "status_code"},
+			[]string{"endpoint", "status_code", "tenant"},
 		)
-		
+
+		if opts.EnableSummaries {
+			mc.requestDurationSummary = newLatencySummary(
+				"api_request_duration_seconds_summary",
+				"API request latency with sliding quantile objectives, parallel to api_request_duration_seconds",
+				[]string{"endpoint", "status_code", "tenant"},
+			)
+		}
+
 		mc.requestCounter = promauto.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "api_requests_total",
-				Help: "Total requests by status code",
+				Help: "Total requests by status code and tenant",
 			},
-			[]string{"status_code"},
+			[]string{"status_code", "tenant"},
 		)
-		
+
 		mc.queueDepth = promauto.NewGauge(
 			prometheus.GaugeOpts{
 				Name: "redis_queue_depth",
 				Help: "Current job queue size",
 			},
 		)
-		
-		mc.successfulOps = promauto.NewCounter(
+
+		mc.successfulOps = promauto.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "api_successful_operations_total",
-				Help: "Completed jobs for cost tracking",
+				Help: "Completed jobs for cost tracking, by tenant",
+			},
+			[]string{"tenant"},
+		)
+
+		mc.tenantOverflow = promauto.NewCounter(
+			prometheus.CounterOpts{
+				Name: "metrics_tenant_overflow_total",
+				Help: "Requests whose tenant label was folded into \"__overflow__\" because pkg/metrics/tenantstats' MaxTenantCardinality was reached",
+			},
+		)
+
+		mc.redisPipelineBatchSize = promauto.NewHistogram(
+			prometheus.HistogramOpts{
+				Name:    "blunderbuss_redis_pipeline_batch_size",
+				Help:    "Number of job enqueues flushed per pipelined XADD batch, when REDIS_PIPE_PERIOD is enabled",
+				Buckets: []float64{1, 2, 5, 10, 20, 50, 100, 200},
+			},
+		)
+
+		mc.redisPipelineFlushDuration = promauto.NewHistogram(
+			prometheus.HistogramOpts{
+				Name:    "blunderbuss_redis_pipeline_flush_duration_seconds",
+				Help:    "Time to execute a pipelined job enqueue flush, when REDIS_PIPE_PERIOD is enabled",
+				Buckets: []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 2},
 			},
 		)
 	}
-	
+
 	// Worker Service metrics (requirements 1.2, 1.3, 1.4, 1.7)
 	if serviceName == "worker" {
 		mc.queueWaitTime = promauto.NewHistogramVec(
@@ -95,7 +258,7 @@ func NewMetricsCollector(serviceName string) *MetricsCollector {
 			},
 			[]string{},
 		)
-		
+
 		mc.engineConnectionTime = promauto.NewHistogramVec(
 			prometheus.HistogramOpts{
 				Name:    "worker_engine_connection_seconds",
@@ -104,7 +267,7 @@ func NewMetricsCollector(serviceName string) *MetricsCollector {
 			},
 			[]string{},
 		)
-		
+
 		mc.engineComputeTime = promauto.NewHistogramVec(
 			prometheus.HistogramOpts{
 				Name:    "worker_engine_computation_seconds",
@@ -113,7 +276,15 @@ func NewMetricsCollector(serviceName string) *MetricsCollector {
 			},
 			[]string{},
 		)
-		
+
+		if opts.EnableSummaries {
+			mc.engineComputeTimeSummary = newLatencySummary(
+				"worker_engine_computation_seconds_summary",
+				"Stockfish computation time with sliding quantile objectives, parallel to worker_engine_computation_seconds",
+				[]string{},
+			)
+		}
+
 		mc.resultPublishTime = promauto.NewHistogramVec(
 			prometheus.HistogramOpts{
 				Name:    "worker_result_publish_seconds",
@@ -122,7 +293,7 @@ func NewMetricsCollector(serviceName string) *MetricsCollector {
 			},
 			[]string{},
 		)
-		
+
 		mc.totalProcessingTime = promauto.NewHistogramVec(
 			prometheus.HistogramOpts{
 				Name:    "worker_total_processing_seconds",
@@ -131,29 +302,162 @@ func NewMetricsCollector(serviceName string) *MetricsCollector {
 			},
 			[]string{},
 		)
-		
+
+		if opts.EnableSummaries {
+			mc.totalProcessingTimeSummary = newLatencySummary(
+				"worker_total_processing_seconds_summary",
+				"Total job processing time with sliding quantile objectives, parallel to worker_total_processing_seconds",
+				[]string{},
+			)
+		}
+
 		mc.idleTime = promauto.NewCounter(
 			prometheus.CounterOpts{
 				Name: "worker_idle_time_seconds",
 				Help: "Time spent waiting for jobs",
 			},
 		)
-		
+
 		mc.idlePercentage = promauto.NewGauge(
 			prometheus.GaugeOpts{
 				Name: "worker_idle_percentage",
-				Help: "Idle time percentage (0-100)",
+				Help: "EWMA-smoothed idle time percentage (0-100)",
 			},
 		)
-		
+
+		mc.idlePercentageWindow = promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "worker_idle_percentage_window",
+				Help: "Idle time percentage (0-100) over a trailing window, load-average style",
+			},
+			[]string{"window"},
+		)
+
 		mc.activeJobs = promauto.NewGauge(
 			prometheus.GaugeOpts{
 				Name: "worker_active_jobs",
 				Help: "Current number of jobs being processed",
 			},
 		)
+
+		mc.jobsByStatus = promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "worker_jobs_by_status",
+				Help: "Tracked jobs by jobstats lifecycle status",
+			},
+			[]string{"status"},
+		)
+
+		mc.enginePoolInUse = promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "worker_engine_pool_in_use",
+				Help: "Pooled Stockfish connections currently checked out",
+			},
+			[]string{"engine_addr"},
+		)
+
+		mc.enginePoolIdle = promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "worker_engine_pool_idle",
+				Help: "Pooled Stockfish connections currently idle",
+			},
+			[]string{"engine_addr"},
+		)
+
+		mc.enginePoolWaitTime = promauto.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "worker_engine_pool_wait_seconds",
+				Help:    "Time spent waiting for a free pooled Stockfish connection",
+				Buckets: []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 2, 5},
+			},
+			[]string{"engine_addr"},
+		)
+
+		mc.enginePoolEvictions = promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "worker_engine_pool_evictions_total",
+				Help: "Pooled Stockfish connections dropped for exceeding max lifetime or failing a health check",
+			},
+			[]string{"engine_addr"},
+		)
+
+		mc.resultBatchSize = promauto.NewHistogram(
+			prometheus.HistogramOpts{
+				Name:    "worker_result_batch_size",
+				Help:    "Number of results flushed per pipelined SET+PUBLISH batch, when result batching is enabled",
+				Buckets: []float64{1, 2, 5, 10, 20, 50, 100, 200},
+			},
+		)
+
+		mc.resultFlushLatency = promauto.NewHistogram(
+			prometheus.HistogramOpts{
+				Name:    "worker_result_flush_latency_seconds",
+				Help:    "Time to execute a pipelined result flush, when result batching is enabled",
+				Buckets: []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 2},
+			},
+		)
+
+		// CPU time class breakdown, mirroring runtime/metrics' /cpu/classes
+		// tree plus the split procfs utime/stime this worker already reads.
+		mc.cpuSecondsUser = promauto.NewCounter(
+			prometheus.CounterOpts{
+				Name: "worker_cpu_seconds_user_total",
+				Help: "User-mode CPU-seconds consumed (procfs utime)",
+			},
+		)
+		mc.cpuSecondsSystem = promauto.NewCounter(
+			prometheus.CounterOpts{
+				Name: "worker_cpu_seconds_system_total",
+				Help: "Kernel-mode CPU-seconds consumed (procfs stime)",
+			},
+		)
+		mc.cpuSecondsGCTotal = promauto.NewCounter(
+			prometheus.CounterOpts{
+				Name: "worker_cpu_seconds_gc_total",
+				Help: "CPU-seconds spent across all garbage collection activity (/cpu/classes/gc/total:cpu-seconds)",
+			},
+		)
+		mc.cpuSecondsGCPause = promauto.NewCounter(
+			prometheus.CounterOpts{
+				Name: "worker_cpu_seconds_gc_pause_total",
+				Help: "CPU-seconds spent in stop-the-world GC pauses (/cpu/classes/gc/pause:cpu-seconds)",
+			},
+		)
+		mc.cpuSecondsIdle = promauto.NewCounter(
+			prometheus.CounterOpts{
+				Name: "worker_cpu_seconds_idle_total",
+				Help: "CPU-seconds the Go runtime reports idle (/cpu/classes/idle:cpu-seconds)",
+			},
+		)
+		mc.cpuSecondsScavenge = promauto.NewCounter(
+			prometheus.CounterOpts{
+				Name: "worker_cpu_seconds_scavenge_total",
+				Help: "CPU-seconds spent returning memory to the OS (/cpu/classes/scavenge/total:cpu-seconds)",
+			},
+		)
+
+		mc.cpuThrottledSeconds = promauto.NewCounter(
+			prometheus.CounterOpts{
+				Name: "cpu_throttled_seconds_total",
+				Help: "Cumulative time this process's cgroup was CPU-throttled (cgroup cpu.stat throttled_usec/throttled_time)",
+			},
+		)
+
+		mc.cpuThrottleEvents = promauto.NewCounter(
+			prometheus.CounterOpts{
+				Name: "cpu_throttle_events_total",
+				Help: "Number of periods this process's cgroup was CPU-throttled (cgroup cpu.stat nr_throttled)",
+			},
+		)
+
+		mc.cpuPressureAvg10 = promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "cpu_pressure_avg10",
+				Help: "10-second average CPU pressure (PSI 'some' avg10) from cgroup cpu.pressure",
+			},
+		)
 	}
-	
+
 	// Circuit breaker metrics (requirement 3.8)
 	mc.circuitState = promauto.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -162,7 +466,7 @@ func NewMetricsCollector(serviceName string) *MetricsCollector {
 		},
 		[]string{"service", "component"},
 	)
-	
+
 	mc.circuitFailures = promauto.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "circuit_breaker_failures_total",
@@ -170,7 +474,23 @@ func NewMetricsCollector(serviceName string) *MetricsCollector {
 		},
 		[]string{"service", "component"},
 	)
-	
+
+	mc.circuitAsyncQueue = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "circuit_breaker_async_queue_depth",
+			Help: "Number of in-flight submissions an AsyncCircuitBreaker is currently running",
+		},
+		[]string{"service", "component"},
+	)
+
+	mc.circuitAsyncDropped = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "circuit_breaker_async_dropped_total",
+			Help: "Submissions an AsyncCircuitBreaker dropped instead of enqueuing, because it was open or its queue was full",
+		},
+		[]string{"service", "component"},
+	)
+
 	// Retry metrics (requirement 4.6)
 	mc.retryAttempts = promauto.NewCounterVec(
 		prometheus.CounterOpts{
@@ -179,22 +499,81 @@ func NewMetricsCollector(serviceName string) *MetricsCollector {
 		},
 		[]string{"service", "operation", "attempt_number"},
 	)
-	
+
+	// Redis connection topology metrics
+	mc.redisFailovers = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "redis_sentinel_failovers_total",
+			Help: "Sentinel-backed Redis client reconnects to a new master",
+		},
+		[]string{"service", "master_name"},
+	)
+
+	// Job stream (XREADGROUP) metrics, shared by the api health check and the
+	// worker's abandoned-entry reaper
+	mc.streamPending = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "blunderbuss_stream_pending",
+			Help: "Total XPENDING entries across every shard's job stream, not yet XACKed",
+		},
+	)
+
+	mc.streamReclaimed = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "blunderbuss_stream_reclaimed_total",
+			Help: "Stream entries XCLAIMed from a consumer that went idle past the reclaim threshold",
+		},
+	)
+
+	mc.streamConsumerLag = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "blunderbuss_stream_consumer_lag_seconds",
+			Help: "Idle time of the oldest pending job stream entry, across every shard",
+		},
+	)
+
+	// Move cache metrics
+	mc.moveCacheHits = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "move_cache_hits_total",
+			Help: "Move cache hits by tier (local or redis)",
+		},
+		[]string{"tier"},
+	)
+
+	// Periodic job scheduler metrics
+	mc.schedulerDrift = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "scheduler_fire_drift_seconds",
+			Help:    "Delay between a periodic template's scheduled fire time and when the leader actually enqueued it",
+			Buckets: []float64{0.5, 1, 2, 5, 10, 30, 60, 120, 300},
+		},
+	)
+
+	mc.schedulerSkippedFires = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "scheduler_skipped_fires_total",
+			Help: "Missed periodic fires dropped under the skip missed-fires policy",
+		},
+	)
+
 	// Cost efficiency metrics (requirements 5.1, 5.2, 5.3, 5.4)
-	mc.cpuSeconds = promauto.NewCounter(
+	mc.cpuSeconds = promauto.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "service_cpu_seconds_total",
-			Help: "Total CPU-seconds consumed",
+			Help: "Total CPU-seconds consumed, by tenant",
 		},
+		[]string{"tenant"},
 	)
-	
-	mc.costEfficiency = promauto.NewGauge(
+
+	mc.costEfficiency = promauto.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "cost_efficiency_ratio",
-			Help: "Operations per CPU-second",
+			Help: "Operations per CPU-second, by tenant",
 		},
+		[]string{"tenant"},
 	)
-	
+
 	mc.replicaCount = promauto.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "service_replica_count",
@@ -202,7 +581,7 @@ func NewMetricsCollector(serviceName string) *MetricsCollector {
 		},
 		[]string{"service"},
 	)
-	
+
 	mc.averageReplicas = promauto.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "service_average_replicas",
@@ -210,7 +589,7 @@ func NewMetricsCollector(serviceName string) *MetricsCollector {
 		},
 		[]string{"service"},
 	)
-	
+
 	// Queue metrics (requirement 5.7)
 	mc.queueDepthVariance = promauto.NewGauge(
 		prometheus.GaugeOpts{
@@ -218,7 +597,7 @@ func NewMetricsCollector(serviceName string) *MetricsCollector {
 			Help: "Standard deviation of queue depth over time windows",
 		},
 	)
-	
+
 	// Scaling metrics (requirement 5.8)
 	mc.scalingEvents = promauto.NewCounterVec(
 		prometheus.CounterOpts{
@@ -227,29 +606,129 @@ func NewMetricsCollector(serviceName string) *MetricsCollector {
 		},
 		[]string{"service", "direction"},
 	)
-	
+
 	mc.scalingEventsRatio = promauto.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "scaling_events_ratio",
-			Help: "Ratio of scale-up events to scale-down events for tuning analysis",
+			Help: "Ratio of scale-up events to scale-down events over window (15m, 1h, or 24h), for tuning analysis. Unset for a window with zero scale-down events - see scaling_events_no_downscale",
 		},
-		[]string{"service"},
+		[]string{"service", "window"},
+	)
+
+	mc.scalingEventsNoDownscale = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "scaling_events_no_downscale",
+			Help: "1 if service has had zero scale-down events within the window, so scaling_events_ratio being unset can be told apart from a genuine ratio of zero",
+		},
+		[]string{"service", "window"},
+	)
+
+	// HPA/KEDA autoscaler state (requirement: correlate ReplicaTracker's
+	// deployment observations with the autoscaler driving them)
+	mc.hpaCurrentReplicas = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "hpa_current_replicas",
+			Help: "Current replica count as last reported by the HPA/ScaledObject status",
+		},
+		[]string{"service", "hpa"},
+	)
+
+	mc.hpaDesiredReplicas = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "hpa_desired_replicas",
+			Help: "Desired replica count as last reported by the HPA/ScaledObject status",
+		},
+		[]string{"service", "hpa"},
+	)
+
+	mc.hpaMinReplicas = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "hpa_min_replicas",
+			Help: "Configured minimum replica count on the HPA/ScaledObject spec",
+		},
+		[]string{"service", "hpa"},
+	)
+
+	mc.hpaMaxReplicas = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "hpa_max_replicas",
+			Help: "Configured maximum replica count on the HPA/ScaledObject spec",
+		},
+		[]string{"service", "hpa"},
+	)
+
+	mc.hpaTargetUtilization = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "hpa_target_utilization",
+			Help: "Current value of each metric the HPA/ScaledObject is scaling on, against its target",
+		},
+		[]string{"service", "hpa", "metric"},
+	)
+
+	mc.hpaCondition = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "hpa_condition",
+			Help: "1 if the HPA's condition of this type currently has this status, else 0",
+		},
+		[]string{"service", "hpa", "type", "status"},
+	)
+
+	mc.scalingEventCause = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "scaling_event_cause_total",
+			Help: "Scaling events annotated with the HPA's most recent ScalingActive/ScalingLimited condition reason, for interpreting the scale-up/down ratio",
+		},
+		[]string{"service", "reason"},
+	)
+
+	// Self-verification drift metrics (pkg/metrics/verifier)
+	mc.metricsDrift = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "metrics_drift_total",
+			Help: "Times the verifier's PromQL readback disagreed with this process's in-memory metric value by more than its tolerance",
+		},
+		[]string{"metric", "service"},
+	)
+
+	// Audit log writer backlog
+	mc.auditLogBufferSize = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "audit_log_writer_buffer_size",
+			Help: "AuditLogWriter.GetBufferSize() for each writer registered with a ChanneledLogMux",
+		},
+		[]string{"service", "writer"},
 	)
-	
+
 	return mc
 }
 
-// RecordRequestDuration records API request duration
-func (mc *MetricsCollector) RecordRequestDuration(endpoint, statusCode string, duration time.Duration) {
+// RecordRequestDuration records API request duration for tenant, the
+// caller's already-cardinality-bounded tenant label (see
+// pkg/metrics/tenantstats.Aggregator.Resolve).
+func (mc *MetricsCollector) RecordRequestDuration(endpoint, statusCode, tenant string, duration time.Duration) {
 	if mc.requestDuration != nil {
-		mc.requestDuration.WithLabelValues(endpoint, statusCode).Observe(duration.Seconds())
+		mc.requestDuration.WithLabelValues(endpoint, statusCode, tenant).Observe(duration.Seconds())
+	}
+	if mc.requestDurationSummary != nil {
+		mc.requestDurationSummary.WithLabelValues(endpoint, statusCode, tenant).Observe(duration.Seconds())
 	}
 }
 
-// IncrementRequestCounter increments API request counter
-func (mc *MetricsCollector) IncrementRequestCounter(statusCode string) {
+// IncrementRequestCounter increments API request counter for tenant, the
+// caller's already-cardinality-bounded tenant label (see
+// pkg/metrics/tenantstats.Aggregator.Resolve).
+func (mc *MetricsCollector) IncrementRequestCounter(statusCode, tenant string) {
 	if mc.requestCounter != nil {
-		mc.requestCounter.WithLabelValues(statusCode).Inc()
+		mc.requestCounter.WithLabelValues(statusCode, tenant).Inc()
+	}
+}
+
+// IncrementTenantOverflow records that a tenant was folded into the
+// "__overflow__" label because pkg/metrics/tenantstats' MaxTenantCardinality
+// was already reached.
+func (mc *MetricsCollector) IncrementTenantOverflow() {
+	if mc.tenantOverflow != nil {
+		mc.tenantOverflow.Inc()
 	}
 }
 
@@ -279,6 +758,9 @@ func (mc *MetricsCollector) RecordEngineComputeTime(duration time.Duration) {
 	if mc.engineComputeTime != nil {
 		mc.engineComputeTime.WithLabelValues().Observe(duration.Seconds())
 	}
+	if mc.engineComputeTimeSummary != nil {
+		mc.engineComputeTimeSummary.WithLabelValues().Observe(duration.Seconds())
+	}
 }
 
 // RecordResultPublishTime records result publishing time
@@ -293,6 +775,9 @@ func (mc *MetricsCollector) RecordTotalProcessingTime(duration time.Duration) {
 	if mc.totalProcessingTime != nil {
 		mc.totalProcessingTime.WithLabelValues().Observe(duration.Seconds())
 	}
+	if mc.totalProcessingTimeSummary != nil {
+		mc.totalProcessingTimeSummary.WithLabelValues().Observe(duration.Seconds())
+	}
 }
 
 // IncrementIdleTime increments worker idle time
@@ -309,6 +794,14 @@ func (mc *MetricsCollector) SetIdlePercentage(percentage float64) {
 	}
 }
 
+// SetIdlePercentageWindow sets the trailing-window idle time percentage
+// (0-100) for the given window label (e.g. "1m", "5m", "15m")
+func (mc *MetricsCollector) SetIdlePercentageWindow(window string, percentage float64) {
+	if mc.idlePercentageWindow != nil {
+		mc.idlePercentageWindow.WithLabelValues(window).Set(percentage)
+	}
+}
+
 // SetActiveJobs sets the current number of active jobs
 func (mc *MetricsCollector) SetActiveJobs(count float64) {
 	if mc.activeJobs != nil {
@@ -316,6 +809,75 @@ func (mc *MetricsCollector) SetActiveJobs(count float64) {
 	}
 }
 
+// SetJobsByStatus sets the number of tracked jobs currently in the given
+// jobstats lifecycle status
+func (mc *MetricsCollector) SetJobsByStatus(status string, count float64) {
+	if mc.jobsByStatus != nil {
+		mc.jobsByStatus.WithLabelValues(status).Set(count)
+	}
+}
+
+// SetEnginePoolInUse sets the number of pooled Stockfish connections checked
+// out for addr.
+func (mc *MetricsCollector) SetEnginePoolInUse(addr string, count float64) {
+	if mc.enginePoolInUse != nil {
+		mc.enginePoolInUse.WithLabelValues(addr).Set(count)
+	}
+}
+
+// SetEnginePoolIdle sets the number of idle, warm pooled connections for addr.
+func (mc *MetricsCollector) SetEnginePoolIdle(addr string, count float64) {
+	if mc.enginePoolIdle != nil {
+		mc.enginePoolIdle.WithLabelValues(addr).Set(count)
+	}
+}
+
+// RecordEnginePoolWaitTime records time spent waiting for a free pooled
+// connection to addr.
+func (mc *MetricsCollector) RecordEnginePoolWaitTime(addr string, wait time.Duration) {
+	if mc.enginePoolWaitTime != nil {
+		mc.enginePoolWaitTime.WithLabelValues(addr).Observe(wait.Seconds())
+	}
+}
+
+// IncrementEnginePoolEvictions increments the count of pooled connections to
+// addr dropped for exceeding max lifetime or failing a health check.
+func (mc *MetricsCollector) IncrementEnginePoolEvictions(addr string) {
+	if mc.enginePoolEvictions != nil {
+		mc.enginePoolEvictions.WithLabelValues(addr).Inc()
+	}
+}
+
+// RecordResultBatchSize records how many results a pipelined flush wrote
+func (mc *MetricsCollector) RecordResultBatchSize(size float64) {
+	if mc.resultBatchSize != nil {
+		mc.resultBatchSize.Observe(size)
+	}
+}
+
+// RecordResultFlushLatency records how long a pipelined result flush took
+func (mc *MetricsCollector) RecordResultFlushLatency(duration time.Duration) {
+	if mc.resultFlushLatency != nil {
+		mc.resultFlushLatency.Observe(duration.Seconds())
+	}
+}
+
+// RecordRedisPipelineBatchSize records how many job enqueues a pipelined
+// REDIS_PIPE_PERIOD flush wrote
+func (mc *MetricsCollector) RecordRedisPipelineBatchSize(size float64) {
+	if mc.redisPipelineBatchSize != nil {
+		mc.redisPipelineBatchSize.Observe(size)
+	}
+}
+
+// RecordRedisPipelineFlushDuration records how long a pipelined job enqueue
+// flush took
+func (mc *MetricsCollector) RecordRedisPipelineFlushDuration(duration time.Duration) {
+	if mc.redisPipelineFlushDuration != nil {
+		mc.redisPipelineFlushDuration.Observe(duration.Seconds())
+	}
+}
+
 // SetCircuitBreakerState sets circuit breaker state (0=closed, 1=half-open, 2=open)
 func (mc *MetricsCollector) SetCircuitBreakerState(service, component string, state float64) {
 	if mc.circuitState != nil {
@@ -330,6 +892,23 @@ func (mc *MetricsCollector) IncrementCircuitBreakerFailures(service, component s
 	}
 }
 
+// SetCircuitBreakerAsyncQueueDepth sets the number of submissions an
+// AsyncCircuitBreaker is currently running for service/component.
+func (mc *MetricsCollector) SetCircuitBreakerAsyncQueueDepth(service, component string, depth float64) {
+	if mc.circuitAsyncQueue != nil {
+		mc.circuitAsyncQueue.WithLabelValues(service, component).Set(depth)
+	}
+}
+
+// IncrementCircuitBreakerAsyncDropped increments the count of submissions an
+// AsyncCircuitBreaker dropped instead of enqueuing, because it was open or
+// its queue was full.
+func (mc *MetricsCollector) IncrementCircuitBreakerAsyncDropped(service, component string) {
+	if mc.circuitAsyncDropped != nil {
+		mc.circuitAsyncDropped.WithLabelValues(service, component).Inc()
+	}
+}
+
 // IncrementRetryAttempts increments retry attempt counter
 func (mc *MetricsCollector) IncrementRetryAttempts(service, operation, attemptNumber string) {
 	if mc.retryAttempts != nil {
@@ -337,24 +916,143 @@ func (mc *MetricsCollector) IncrementRetryAttempts(service, operation, attemptNu
 	}
 }
 
-// IncrementSuccessfulOps increments successful operations counter
-func (mc *MetricsCollector) IncrementSuccessfulOps() {
+// IncrementRedisFailovers increments the count of Sentinel master switches
+// observed by a service's redisconn client.
+func (mc *MetricsCollector) IncrementRedisFailovers(service, masterName string) {
+	if mc.redisFailovers != nil {
+		mc.redisFailovers.WithLabelValues(service, masterName).Inc()
+	}
+}
+
+// IncrementMoveCacheHits increments the move cache hit counter for tier
+// ("local" or "redis").
+func (mc *MetricsCollector) IncrementMoveCacheHits(tier string) {
+	if mc.moveCacheHits != nil {
+		mc.moveCacheHits.WithLabelValues(tier).Inc()
+	}
+}
+
+// SetStreamPending sets the total XPENDING entry count across every shard's
+// job stream.
+func (mc *MetricsCollector) SetStreamPending(count float64) {
+	if mc.streamPending != nil {
+		mc.streamPending.Set(count)
+	}
+}
+
+// IncrementStreamReclaimed increments the count of stream entries XCLAIMed
+// from a consumer that went idle past the reclaim threshold.
+func (mc *MetricsCollector) IncrementStreamReclaimed(count float64) {
+	if mc.streamReclaimed != nil {
+		mc.streamReclaimed.Add(count)
+	}
+}
+
+// SetStreamConsumerLag sets the idle time of the oldest pending job stream
+// entry, across every shard.
+func (mc *MetricsCollector) SetStreamConsumerLag(lag time.Duration) {
+	if mc.streamConsumerLag != nil {
+		mc.streamConsumerLag.Set(lag.Seconds())
+	}
+}
+
+// RecordSchedulerDrift records how far behind schedule a periodic fire was
+func (mc *MetricsCollector) RecordSchedulerDrift(drift time.Duration) {
+	if mc.schedulerDrift != nil {
+		mc.schedulerDrift.Observe(drift.Seconds())
+	}
+}
+
+// IncrementSchedulerSkippedFires increments the count of missed periodic
+// fires dropped under the skip missed-fires policy
+func (mc *MetricsCollector) IncrementSchedulerSkippedFires(count float64) {
+	if mc.schedulerSkippedFires != nil {
+		mc.schedulerSkippedFires.Add(count)
+	}
+}
+
+// IncrementSuccessfulOps increments successful operations counter for tenant
+func (mc *MetricsCollector) IncrementSuccessfulOps(tenant string) {
 	if mc.successfulOps != nil {
-		mc.successfulOps.Inc()
+		mc.successfulOps.WithLabelValues(tenant).Inc()
 	}
 }
 
-// IncrementCPUSeconds increments CPU seconds counter
-func (mc *MetricsCollector) IncrementCPUSeconds(seconds float64) {
+// IncrementCPUSeconds increments CPU seconds counter for tenant
+func (mc *MetricsCollector) IncrementCPUSeconds(tenant string, seconds float64) {
 	if mc.cpuSeconds != nil {
-		mc.cpuSeconds.Add(seconds)
+		mc.cpuSeconds.WithLabelValues(tenant).Add(seconds)
+	}
+}
+
+// IncrementCPUSecondsUser increments the user-mode CPU-seconds counter
+func (mc *MetricsCollector) IncrementCPUSecondsUser(seconds float64) {
+	if mc.cpuSecondsUser != nil {
+		mc.cpuSecondsUser.Add(seconds)
+	}
+}
+
+// IncrementCPUSecondsSystem increments the kernel-mode CPU-seconds counter
+func (mc *MetricsCollector) IncrementCPUSecondsSystem(seconds float64) {
+	if mc.cpuSecondsSystem != nil {
+		mc.cpuSecondsSystem.Add(seconds)
+	}
+}
+
+// IncrementCPUSecondsGCTotal increments total GC CPU-seconds
+func (mc *MetricsCollector) IncrementCPUSecondsGCTotal(seconds float64) {
+	if mc.cpuSecondsGCTotal != nil {
+		mc.cpuSecondsGCTotal.Add(seconds)
+	}
+}
+
+// IncrementCPUSecondsGCPause increments stop-the-world GC pause CPU-seconds
+func (mc *MetricsCollector) IncrementCPUSecondsGCPause(seconds float64) {
+	if mc.cpuSecondsGCPause != nil {
+		mc.cpuSecondsGCPause.Add(seconds)
+	}
+}
+
+// IncrementCPUSecondsIdle increments runtime-reported idle CPU-seconds
+func (mc *MetricsCollector) IncrementCPUSecondsIdle(seconds float64) {
+	if mc.cpuSecondsIdle != nil {
+		mc.cpuSecondsIdle.Add(seconds)
+	}
+}
+
+// IncrementCPUSecondsScavenge increments memory-scavenging CPU-seconds
+func (mc *MetricsCollector) IncrementCPUSecondsScavenge(seconds float64) {
+	if mc.cpuSecondsScavenge != nil {
+		mc.cpuSecondsScavenge.Add(seconds)
+	}
+}
+
+// IncrementCPUThrottledSeconds increments cumulative cgroup CPU throttled
+// time
+func (mc *MetricsCollector) IncrementCPUThrottledSeconds(seconds float64) {
+	if mc.cpuThrottledSeconds != nil {
+		mc.cpuThrottledSeconds.Add(seconds)
 	}
 }
 
-// SetCostEfficiency sets the cost efficiency ratio
-func (mc *MetricsCollector) SetCostEfficiency(ratio float64) {
+// IncrementCPUThrottleEvents increments the cgroup CPU throttle event count
+func (mc *MetricsCollector) IncrementCPUThrottleEvents(count float64) {
+	if mc.cpuThrottleEvents != nil {
+		mc.cpuThrottleEvents.Add(count)
+	}
+}
+
+// SetCPUPressureAvg10 sets the cgroup CPU PSI "some" avg10 gauge
+func (mc *MetricsCollector) SetCPUPressureAvg10(avg10 float64) {
+	if mc.cpuPressureAvg10 != nil {
+		mc.cpuPressureAvg10.Set(avg10)
+	}
+}
+
+// SetCostEfficiency sets the cost efficiency ratio for tenant
+func (mc *MetricsCollector) SetCostEfficiency(tenant string, ratio float64) {
 	if mc.costEfficiency != nil {
-		mc.costEfficiency.Set(ratio)
+		mc.costEfficiency.WithLabelValues(tenant).Set(ratio)
 	}
 }
 
@@ -386,11 +1084,192 @@ func (mc *MetricsCollector) IncrementScalingEvents(service, direction string) {
 	}
 }
 
-// SetScalingEventsRatio sets the ratio of scale-up to scale-down events
-func (mc *MetricsCollector) SetScalingEventsRatio(service string, ratio float64) {
+// SetScalingEventsRatio sets the ratio of scale-up to scale-down events for
+// service over the rolling window (one of "15m", "1h", "24h"). Callers must
+// only call this when at least one scale-down has occurred in window - see
+// SetScalingEventsNoDownscale for the zero-downscale case, which should
+// leave the ratio gauge unset rather than publish a misleading value.
+func (mc *MetricsCollector) SetScalingEventsRatio(service, window string, ratio float64) {
 	if mc.scalingEventsRatio != nil {
-		mc.scalingEventsRatio.WithLabelValues(service).Set(ratio)
+		mc.scalingEventsRatio.WithLabelValues(service, window).Set(ratio)
+	}
+}
+
+// SetScalingEventsNoDownscale sets the scaling_events_no_downscale sentinel
+// for service over window, so "ratio is unset because there have been zero
+// scale-downs" can be told apart in PromQL from "ratio is unset because
+// nothing has scaled at all."
+func (mc *MetricsCollector) SetScalingEventsNoDownscale(service, window string, noDownscale bool) {
+	if mc.scalingEventsNoDownscale == nil {
+		return
+	}
+	value := 0.0
+	if noDownscale {
+		value = 1.0
+	}
+	mc.scalingEventsNoDownscale.WithLabelValues(service, window).Set(value)
+}
+
+// SetHPAReplicas sets the current/desired/min/max replica gauges HPA
+// reports for hpa (the HorizontalPodAutoscaler or ScaledObject's name)
+// scaling service.
+func (mc *MetricsCollector) SetHPAReplicas(service, hpa string, current, desired, min, max float64) {
+	if mc.hpaCurrentReplicas != nil {
+		mc.hpaCurrentReplicas.WithLabelValues(service, hpa).Set(current)
+	}
+	if mc.hpaDesiredReplicas != nil {
+		mc.hpaDesiredReplicas.WithLabelValues(service, hpa).Set(desired)
+	}
+	if mc.hpaMinReplicas != nil {
+		mc.hpaMinReplicas.WithLabelValues(service, hpa).Set(min)
+	}
+	if mc.hpaMaxReplicas != nil {
+		mc.hpaMaxReplicas.WithLabelValues(service, hpa).Set(max)
+	}
+}
+
+// SetHPATargetUtilization sets hpa's current value for one of the metrics
+// it scales service on (e.g. "cpu", "memory", or a KEDA trigger name).
+func (mc *MetricsCollector) SetHPATargetUtilization(service, hpa, metric string, value float64) {
+	if mc.hpaTargetUtilization != nil {
+		mc.hpaTargetUtilization.WithLabelValues(service, hpa, metric).Set(value)
+	}
+}
+
+// SetHPACondition records whether hpa's condition of conditionType currently
+// has status (one of the corev1.ConditionStatus strings), so
+// ScalingActive=False or ScalingLimited=True can be alerted on directly.
+func (mc *MetricsCollector) SetHPACondition(service, hpa, conditionType, status string, active bool) {
+	if mc.hpaCondition == nil {
+		return
+	}
+	value := 0.0
+	if active {
+		value = 1.0
 	}
+	mc.hpaCondition.WithLabelValues(service, hpa, conditionType, status).Set(value)
+}
+
+// IncrementScalingEventCause records a scaling event for service annotated
+// with reason - the HPA's most recent ScalingActive/ScalingLimited
+// condition reason - so the scale-up/down ratio can be interpreted (e.g.
+// distinguishing "hit maxReplicas" from "genuine load drop").
+func (mc *MetricsCollector) IncrementScalingEventCause(service, reason string) {
+	if mc.scalingEventCause != nil {
+		mc.scalingEventCause.WithLabelValues(service, reason).Inc()
+	}
+}
+
+// IncrementMetricsDrift records that pkg/metrics/verifier's PromQL readback
+// for metric disagreed with this process's in-memory value for service by
+// more than its configured tolerance.
+func (mc *MetricsCollector) IncrementMetricsDrift(metric, service string) {
+	if mc.metricsDrift != nil {
+		mc.metricsDrift.WithLabelValues(metric, service).Inc()
+	}
+}
+
+// SetAuditLogBufferSize reports writer's current backlog
+// (AuditLogWriter.GetBufferSize()) for a writer registered with service's
+// ChanneledLogMux, so a writer that's falling behind (a stuck webhook, a
+// Kafka producer that can't keep up) shows up before its channel fills and
+// starts blocking every logging call site.
+func (mc *MetricsCollector) SetAuditLogBufferSize(service, writer string, size float64) {
+	if mc.auditLogBufferSize != nil {
+		mc.auditLogBufferSize.WithLabelValues(service, writer).Set(size)
+	}
+}
+
+// AverageReplicas reads back the current service_average_replicas value this
+// process last set for service via SetAverageReplicas, so
+// pkg/metrics/verifier can compare it against Prometheus's own
+// avg_over_time query without this package depending on that package. ok is
+// false if the metric was never set for service (gaugeValue also handles a
+// nil vec, though averageReplicas is registered for every service).
+func (mc *MetricsCollector) AverageReplicas(service string) (value float64, ok bool) {
+	return gaugeValue(mc.averageReplicas, service)
+}
+
+// ScalingEventsRatio reads back the current scaling_events_ratio value this
+// process last set for service and window via SetScalingEventsRatio, for
+// pkg/metrics/verifier to compare against the scaling_events_ratio value
+// Prometheus scraped.
+func (mc *MetricsCollector) ScalingEventsRatio(service, window string) (value float64, ok bool) {
+	return gaugeValue(mc.scalingEventsRatio, service, window)
+}
+
+// TotalProcessingP99 approximates the P99 of worker_total_processing_seconds
+// from this process's own histogram buckets, using the same linear
+// interpolation within the containing bucket that Prometheus's
+// histogram_quantile does server-side. This lets
+// pkg/metrics/verifier compare its local view against the query in-process,
+// without scraping its own /metrics endpoint. ok is false if no observations
+// have been recorded yet.
+func (mc *MetricsCollector) TotalProcessingP99() (value float64, ok bool) {
+	if mc.totalProcessingTime == nil {
+		return 0, false
+	}
+	observer, err := mc.totalProcessingTime.GetMetricWithLabelValues()
+	if err != nil {
+		return 0, false
+	}
+	histogram, ok := observer.(prometheus.Histogram)
+	if !ok {
+		return 0, false
+	}
+	var metric dto.Metric
+	if err := histogram.Write(&metric); err != nil {
+		return 0, false
+	}
+	return quantileFromBuckets(metric.GetHistogram(), 0.99)
+}
+
+// gaugeValue reads back the current value of a label'd gauge via the
+// client_golang dto.Metric.Write readback, the only way to inspect a
+// prometheus.Gauge's value from the same process that set it.
+func gaugeValue(vec *prometheus.GaugeVec, labelValues ...string) (value float64, ok bool) {
+	if vec == nil {
+		return 0, false
+	}
+	gauge, err := vec.GetMetricWithLabelValues(labelValues...)
+	if err != nil {
+		return 0, false
+	}
+	var metric dto.Metric
+	if err := gauge.Write(&metric); err != nil {
+		return 0, false
+	}
+	return metric.GetGauge().GetValue(), true
+}
+
+// quantileFromBuckets linearly interpolates quantile (e.g. 0.99) within the
+// cumulative bucket that first reaches it, mirroring Prometheus's own
+// histogram_quantile. It is an approximation bounded by bucket width, not an
+// exact order statistic - good enough for the verifier's tolerance-based
+// drift check, not for anything precision-sensitive.
+func quantileFromBuckets(h *dto.Histogram, quantile float64) (value float64, ok bool) {
+	if h == nil || h.GetSampleCount() == 0 {
+		return 0, false
+	}
+	target := quantile * float64(h.GetSampleCount())
+	buckets := h.GetBucket()
+
+	var prevCount, prevBound float64
+	for _, b := range buckets {
+		count := float64(b.GetCumulativeCount())
+		bound := b.GetUpperBound()
+		if count >= target {
+			if count == prevCount {
+				return bound, true
+			}
+			fraction := (target - prevCount) / (count - prevCount)
+			return prevBound + fraction*(bound-prevBound), true
+		}
+		prevCount, prevBound = count, bound
+	}
+	// target falls beyond the last finite bucket; report its bound rather
+	// than the +Inf bucket's undefined width.
+	return prevBound, true
 }
 
 // LatencyTracker tracks latency with microsecond precision (requirement 1.1)
@@ -439,10 +1318,71 @@ func (lt *LatencyTracker) GetCorrelationID() string {
 func (lt *LatencyTracker) GetAllCheckpoints() map[string]time.Duration {
 	lt.mu.RLock()
 	defer lt.mu.RUnlock()
-	
+
 	result := make(map[string]time.Duration, len(lt.checkpoints))
 	for k, v := range lt.checkpoints {
 		result[k] = v
 	}
 	return result
 }
+
+// PublishCheckpoints emits every checkpoint this tracker has recorded to
+// mc, as both a HistogramVec and (if mc was built with EnableSummaries) a
+// SummaryVec named "{prefix}_checkpoint_seconds", labeled by checkpoint
+// name - right now Checkpoint only accumulates durations in-process; this
+// is what actually makes them visible in Prometheus.
+func (lt *LatencyTracker) PublishCheckpoints(mc *MetricsCollector, prefix string) {
+	histogram, summary := mc.checkpointMetrics(prefix)
+
+	for name, duration := range lt.GetAllCheckpoints() {
+		if histogram != nil {
+			histogram.WithLabelValues(name).Observe(duration.Seconds())
+		}
+		if summary != nil {
+			summary.WithLabelValues(name).Observe(duration.Seconds())
+		}
+	}
+}
+
+// checkpointHistogramBuckets is shared by every PublishCheckpoints
+// histogram, since a checkpoint's duration is just as wide-ranging as the
+// total_processing_seconds it's a fraction of.
+var checkpointHistogramBuckets = []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 2, 5, 10, 30, 60, 120}
+
+// checkpointMetrics returns (creating and registering on first use)
+// prefix's "{prefix}_checkpoint_seconds" HistogramVec and, if
+// mc.enableSummaries, its paired SummaryVec. prefix is caller-chosen (e.g.
+// "worker_job", "api_move"), so these can't be registered up front in
+// NewMetricsCollectorWithOptions like the fixed latency-critical series.
+func (mc *MetricsCollector) checkpointMetrics(prefix string) (*prometheus.HistogramVec, *prometheus.SummaryVec) {
+	mc.checkpointMu.Lock()
+	defer mc.checkpointMu.Unlock()
+
+	histogram, ok := mc.checkpointHistograms[prefix]
+	if !ok {
+		histogram = promauto.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    prefix + "_checkpoint_seconds",
+				Help:    "Per-checkpoint elapsed time from a LatencyTracker started under the \"" + prefix + "\" prefix",
+				Buckets: checkpointHistogramBuckets,
+			},
+			[]string{"name"},
+		)
+		mc.checkpointHistograms[prefix] = histogram
+	}
+
+	var summary *prometheus.SummaryVec
+	if mc.enableSummaries {
+		summary, ok = mc.checkpointSummaries[prefix]
+		if !ok {
+			summary = newLatencySummary(
+				prefix+"_checkpoint_seconds_summary",
+				"Per-checkpoint elapsed time from a LatencyTracker started under the \""+prefix+"\" prefix, with sliding quantile objectives",
+				[]string{"name"},
+			)
+			mc.checkpointSummaries[prefix] = summary
+		}
+	}
+
+	return histogram, summary
+}
@@ -0,0 +1,180 @@
+// Package tenantstats accumulates per-tenant API usage counts in memory and
+// periodically flushes them to pkg/metrics.MetricsCollector's tenant-labeled
+// Vecs, modeled on frostfs's UsersStat.Update(user, bucket, ...)
+// accumulate-then-flush shape: a request handler's hot path only touches an
+// in-memory map under a mutex, never a Prometheus Vec directly, the same
+// reason api/main.go's REDIS_PIPE_PERIOD pipeliner batches XADDs instead of
+// issuing one per request.
+//
+// Cardinality is bounded by design: once MaxTenantCardinality distinct
+// tenants have been seen, any further tenant folds into the sentinel
+// "__overflow__" label instead of minting a new Prometheus label value, so a
+// runaway or spoofed tenant identifier can't blow up the requestDuration,
+// requestCounter, successfulOps, or cpuSeconds Vecs' cardinality.
+package tenantstats
+
+import (
+	"sync"
+	"time"
+
+	"stockfish-scale/pkg/metrics"
+)
+
+// OverflowTenant is the label value every tenant beyond MaxTenantCardinality
+// folds into.
+const OverflowTenant = "__overflow__"
+
+// DefaultMaxTenantCardinality is the number of distinct tenants tracked
+// before new tenants start folding into OverflowTenant.
+const DefaultMaxTenantCardinality = 1000
+
+// DefaultFlushInterval is how often Start flushes accumulated counts to the
+// MetricsCollector if the caller doesn't specify its own.
+const DefaultFlushInterval = 30 * time.Second
+
+// counts is one tenant's accumulated usage since the last flush.
+type counts struct {
+	successfulOps int64
+	cpuSeconds    float64
+}
+
+// Aggregator accumulates per-tenant {successful_ops, cpu_seconds} and
+// periodically flushes them to a MetricsCollector's tenant-labeled
+// counters/gauge, so per-request instrumentation never touches Prometheus
+// directly and Prometheus-side tenant cardinality is bounded to
+// maxTenantCardinality+1 for the lifetime of the Aggregator, not just within
+// a single flush window: seenTenants tracks every distinct tenant accepted
+// so far and is never reset by flush, unlike stats, which is cleared each
+// interval so deltas aren't double-counted.
+type Aggregator struct {
+	mc                   *metrics.MetricsCollector
+	maxTenantCardinality int
+	flushInterval        time.Duration
+
+	mu          sync.Mutex
+	stats       map[string]*counts
+	seenTenants map[string]struct{}
+
+	stopChan    chan struct{}
+	stoppedChan chan struct{}
+}
+
+// NewAggregator builds an Aggregator flushing to mc every flushInterval
+// (<=0 uses DefaultFlushInterval), bounded to maxTenantCardinality distinct
+// tenants (<=0 uses DefaultMaxTenantCardinality).
+func NewAggregator(mc *metrics.MetricsCollector, maxTenantCardinality int, flushInterval time.Duration) *Aggregator {
+	if maxTenantCardinality <= 0 {
+		maxTenantCardinality = DefaultMaxTenantCardinality
+	}
+	if flushInterval <= 0 {
+		flushInterval = DefaultFlushInterval
+	}
+	return &Aggregator{
+		mc:                   mc,
+		maxTenantCardinality: maxTenantCardinality,
+		flushInterval:        flushInterval,
+		stats:                make(map[string]*counts),
+		seenTenants:          map[string]struct{}{OverflowTenant: {}},
+		stopChan:             make(chan struct{}),
+		stoppedChan:          make(chan struct{}),
+	}
+}
+
+// Resolve folds tenant into OverflowTenant if it is not already tracked and
+// tracking it would exceed maxTenantCardinality, incrementing
+// MetricsCollector's tenant-overflow counter on every such fold (not once
+// per tenant). Callers recording a live observation that can't be
+// batched (e.g. a Histogram's per-request Observe) should call Resolve
+// themselves before passing the tenant label through; Update resolves
+// internally for its own bounded counts.
+func (a *Aggregator) Resolve(tenant string) string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.resolveLocked(tenant)
+}
+
+func (a *Aggregator) resolveLocked(tenant string) string {
+	if _, ok := a.seenTenants[tenant]; ok {
+		return tenant
+	}
+	// seenTenants always holds OverflowTenant too, so it may grow to
+	// maxTenantCardinality real tenants plus that one.
+	if len(a.seenTenants) > a.maxTenantCardinality {
+		if tenant != OverflowTenant {
+			a.mc.IncrementTenantOverflow()
+		}
+		return OverflowTenant
+	}
+	a.seenTenants[tenant] = struct{}{}
+	return tenant
+}
+
+// Update records one request's outcome for tenant, folding it into
+// OverflowTenant under the same cardinality bound Resolve enforces.
+func (a *Aggregator) Update(tenant string, successful bool, cpuSeconds float64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	tenant = a.resolveLocked(tenant)
+	s, ok := a.stats[tenant]
+	if !ok {
+		s = &counts{}
+		a.stats[tenant] = s
+	}
+
+	if successful {
+		s.successfulOps++
+	}
+	s.cpuSeconds += cpuSeconds
+}
+
+// Start begins the periodic flush loop in the background.
+func (a *Aggregator) Start() {
+	go a.run()
+}
+
+// Stop signals the flush loop to exit, flushing once more before returning
+// so counts accumulated since the last tick aren't lost on shutdown.
+func (a *Aggregator) Stop() {
+	close(a.stopChan)
+	<-a.stoppedChan
+}
+
+func (a *Aggregator) run() {
+	defer close(a.stoppedChan)
+
+	ticker := time.NewTicker(a.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			a.flush()
+		case <-a.stopChan:
+			a.flush()
+			return
+		}
+	}
+}
+
+// flush drains the accumulated per-tenant counts into mc's tenant-labeled
+// counters and gauge, resetting each tenant's counts to zero so the next
+// interval's deltas aren't double-counted.
+func (a *Aggregator) flush() {
+	a.mu.Lock()
+	snapshot := a.stats
+	a.stats = make(map[string]*counts, len(snapshot))
+	a.mu.Unlock()
+
+	for tenant, s := range snapshot {
+		for i := int64(0); i < s.successfulOps; i++ {
+			a.mc.IncrementSuccessfulOps(tenant)
+		}
+		if s.cpuSeconds > 0 {
+			a.mc.IncrementCPUSeconds(tenant, s.cpuSeconds)
+		}
+		if s.cpuSeconds > 0 && s.successfulOps > 0 {
+			a.mc.SetCostEfficiency(tenant, float64(s.successfulOps)/s.cpuSeconds)
+		}
+	}
+}
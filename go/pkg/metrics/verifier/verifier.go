@@ -0,0 +1,333 @@
+// Package verifier periodically cross-checks a subset of
+// pkg/metrics.MetricsCollector's scaling gauges against the same values
+// queried back from Prometheus via PromQL, so a divergence between what the
+// worker thinks it reported and what actually landed in Prometheus - a
+// relabeling rule, a scrape gap, a recording rule bug - shows up as a metric
+// and a log line instead of silently skewing autoscaling decisions built on
+// top of these numbers.
+package verifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/api"
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+
+	"stockfish-scale/pkg/logging"
+	"stockfish-scale/pkg/metrics"
+)
+
+// Config holds everything NewVerifier needs, populated from VERIFIER_*
+// environment variables by ConfigFromEnv.
+type Config struct {
+	// PrometheusURL is the base URL of the Prometheus server to query back
+	// against, e.g. "http://prometheus:9090". An empty URL disables the
+	// verifier entirely (NewVerifier returns nil, nil), the same
+	// graceful-degradation convention pkg/k8s.NewReplicaTracker uses for a
+	// cluster it isn't running in.
+	PrometheusURL string
+
+	// Service is the "service" label value this process reports its own
+	// scaling metrics under, and queries Prometheus back for.
+	Service string
+
+	// QueryInterval is how often the verifier re-queries Prometheus.
+	QueryInterval time.Duration
+
+	// Tolerance is the maximum fractional difference
+	// (|local-remote|/max(|local|,|remote|), see relativeDrift) between a
+	// local and queried-back value before it counts as drift.
+	Tolerance float64
+
+	// DriftThreshold is how many consecutive samples a metric must drift by
+	// more than Tolerance before IncrementMetricsDrift fires, so one scrape
+	// interval's transient lag between a Set and the next scrape doesn't
+	// log and count as drift on its own.
+	DriftThreshold int
+}
+
+// ConfigFromEnv reads VERIFIER_* environment variables, defaulting
+// PrometheusURL to empty (disabled) so deployments that don't set it don't
+// pay for a verifier that has nothing to query.
+func ConfigFromEnv() Config {
+	return Config{
+		PrometheusURL:  getenv("VERIFIER_PROMETHEUS_URL", ""),
+		Service:        getenv("VERIFIER_SERVICE", "worker"),
+		QueryInterval:  getenvDuration("VERIFIER_QUERY_INTERVAL", time.Minute),
+		Tolerance:      getenvFloat("VERIFIER_TOLERANCE", 0.25),
+		DriftThreshold: getenvInt("VERIFIER_DRIFT_THRESHOLD", 3),
+	}
+}
+
+// CheckResult is one metric's local-vs-Prometheus comparison from the most
+// recent tick.
+type CheckResult struct {
+	Metric          string  `json:"metric"`
+	Query           string  `json:"query"`
+	LocalValue      float64 `json:"local_value"`
+	RemoteValue     float64 `json:"remote_value"`
+	Drift           float64 `json:"drift"`
+	DriftStreak     int     `json:"drift_streak"`
+	WithinTolerance bool    `json:"within_tolerance"`
+}
+
+// Report is the full set of CheckResults from the verifier's most recent
+// tick, returned by DebugHandler for operator inspection.
+type Report struct {
+	CheckedAt time.Time     `json:"checked_at"`
+	Service   string        `json:"service"`
+	Results   []CheckResult `json:"results"`
+}
+
+// Verifier runs ConfigFromEnv's query loop against a Prometheus HTTP API
+// client, comparing each query's result back against the matching
+// MetricsCollector getter.
+type Verifier struct {
+	cfg        Config
+	api        v1.API
+	metricsCol *metrics.MetricsCollector
+	logger     logging.Logger
+
+	driftStreak map[string]int
+
+	stopChan    chan struct{}
+	stoppedChan chan struct{}
+
+	latest Report
+}
+
+// NewVerifier builds a Verifier from cfg, or returns (nil, nil) if
+// cfg.PrometheusURL is empty - the same graceful-degradation convention
+// pkg/k8s.NewReplicaTracker uses when it isn't running in a cluster, so
+// worker/main.go can call this unconditionally regardless of whether a
+// Prometheus endpoint is configured for this deployment.
+func NewVerifier(cfg Config, metricsCol *metrics.MetricsCollector, logger logging.Logger) (*Verifier, error) {
+	if cfg.PrometheusURL == "" {
+		logger.Info("VERIFIER_PROMETHEUS_URL not set, metrics self-verification disabled")
+		return nil, nil
+	}
+
+	client, err := api.NewClient(api.Config{Address: cfg.PrometheusURL})
+	if err != nil {
+		return nil, fmt.Errorf("verifier: building Prometheus client: %w", err)
+	}
+
+	return &Verifier{
+		cfg:         cfg,
+		api:         v1.NewAPI(client),
+		metricsCol:  metricsCol,
+		logger:      logger,
+		driftStreak: make(map[string]int),
+		stopChan:    make(chan struct{}),
+		stoppedChan: make(chan struct{}),
+	}, nil
+}
+
+// Start begins the query loop in the background.
+func (v *Verifier) Start() {
+	go v.run()
+}
+
+// Stop signals the query loop to exit and waits for it to do so.
+func (v *Verifier) Stop() {
+	close(v.stopChan)
+	<-v.stoppedChan
+}
+
+func (v *Verifier) run() {
+	defer close(v.stoppedChan)
+
+	ticker := time.NewTicker(v.cfg.QueryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-v.stopChan:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), v.cfg.QueryInterval)
+			v.tick(ctx)
+			cancel()
+		}
+	}
+}
+
+// check is one metric's query against Prometheus and its matching local
+// readback, so tick can run the same comparison for every tracked metric.
+type check struct {
+	name  string
+	query string
+	local func() (float64, bool)
+}
+
+func (v *Verifier) checks() []check {
+	service := v.cfg.Service
+	return []check{
+		{
+			name:  "service_average_replicas",
+			query: fmt.Sprintf(`avg_over_time(service_replica_count{service=%q}[1h])`, service),
+			local: func() (float64, bool) { return v.metricsCol.AverageReplicas(service) },
+		},
+		{
+			name:  "scaling_events_ratio",
+			query: fmt.Sprintf(`scaling_events_ratio{service=%q,window="1h"}`, service),
+			local: func() (float64, bool) { return v.metricsCol.ScalingEventsRatio(service, "1h") },
+		},
+		{
+			name:  "worker_total_processing_p99",
+			query: `histogram_quantile(0.99, sum(rate(worker_total_processing_seconds_bucket[5m])) by (le))`,
+			local: v.metricsCol.TotalProcessingP99,
+		},
+	}
+}
+
+func (v *Verifier) tick(ctx context.Context) {
+	report := Report{CheckedAt: time.Now(), Service: v.cfg.Service}
+
+	for _, c := range v.checks() {
+		localValue, ok := c.local()
+		if !ok {
+			continue
+		}
+
+		remoteValue, err := v.queryScalar(ctx, c.query)
+		if err != nil {
+			v.logger.WithFields(map[string]interface{}{
+				"metric": c.name,
+				"query":  c.query,
+			}).Warn("Verifier query failed: " + err.Error())
+			continue
+		}
+
+		drift := relativeDrift(localValue, remoteValue)
+		withinTolerance := drift <= v.cfg.Tolerance
+
+		if withinTolerance {
+			v.driftStreak[c.name] = 0
+		} else {
+			v.driftStreak[c.name]++
+			if v.driftStreak[c.name] >= v.cfg.DriftThreshold {
+				v.metricsCol.IncrementMetricsDrift(c.name, v.cfg.Service)
+				v.logger.WithFields(map[string]interface{}{
+					"metric":       c.name,
+					"local_value":  localValue,
+					"remote_value": remoteValue,
+					"drift":        drift,
+					"drift_streak": v.driftStreak[c.name],
+				}).Warn("Metrics verifier drift exceeded tolerance")
+			}
+		}
+
+		report.Results = append(report.Results, CheckResult{
+			Metric:          c.name,
+			Query:           c.query,
+			LocalValue:      localValue,
+			RemoteValue:     remoteValue,
+			Drift:           drift,
+			DriftStreak:     v.driftStreak[c.name],
+			WithinTolerance: withinTolerance,
+		})
+	}
+
+	v.latest = report
+}
+
+// queryScalar runs query as an instant query and returns its single sample's
+// value, the shape every check in checks() produces.
+func (v *Verifier) queryScalar(ctx context.Context, query string) (float64, error) {
+	result, warnings, err := v.api.Query(ctx, query, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	for _, w := range warnings {
+		v.logger.WithField("query", query).Warn("Prometheus query warning: " + w)
+	}
+
+	vector, ok := result.(model.Vector)
+	if !ok || len(vector) == 0 {
+		return 0, fmt.Errorf("query returned no samples")
+	}
+	return float64(vector[0].Value), nil
+}
+
+// relativeDrift returns the fractional difference between local and remote,
+// scaled by whichever is larger so a drift check isn't asymmetric about
+// which side over- or under-reports. Two exact zeros are defined as no
+// drift.
+func relativeDrift(local, remote float64) float64 {
+	denominator := math.Max(math.Abs(local), math.Abs(remote))
+	if denominator == 0 {
+		return 0
+	}
+	return math.Abs(local-remote) / denominator
+}
+
+// Report returns the results of the most recently completed tick, for
+// DebugHandler to serialize. Its zero value (before the first tick runs) has
+// a nil Results slice.
+func (v *Verifier) Report() Report {
+	return v.latest
+}
+
+// DebugHandler serves the latest Report as JSON, for an operator checking
+// whether the exported scaling metrics still agree with what Prometheus
+// actually scraped.
+func (v *Verifier) DebugHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(v.Report()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
+
+func getenv(k, def string) string {
+	if v := os.Getenv(k); v != "" {
+		return v
+	}
+	return def
+}
+
+func getenvInt(k string, def int) int {
+	v := os.Getenv(k)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return def
+	}
+	return n
+}
+
+func getenvFloat(k string, def float64) float64 {
+	v := os.Getenv(k)
+	if v == "" {
+		return def
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return def
+	}
+	return f
+}
+
+func getenvDuration(k string, def time.Duration) time.Duration {
+	v := os.Getenv(k)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return def
+	}
+	return d
+}
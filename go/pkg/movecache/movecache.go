@@ -0,0 +1,196 @@
+// Package movecache implements a two-tier cache for repeated
+// (FEN, Elo, MoveTimeMs) move requests, modeled on the local-cache-in-
+// front-of-a-shared-cache layering Mattermost's reaction store uses: a
+// size-bounded, TTL'd in-process LRU (tier "local") sits in front of a
+// Redis GET/SETEX tier (tier "redis") shared across api replicas. A hit in
+// either tier lets moveHandler skip the job queue and Stockfish entirely.
+package movecache
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"stockfish-scale/pkg/keys"
+)
+
+// Entry is the cached response for a (FEN, Elo, MoveTimeMs) query,
+// mirroring api's MoveResponse fields.
+type Entry struct {
+	BestMove string `json:"bestmove"`
+	Ponder   string `json:"ponder,omitempty"`
+	Info     string `json:"info,omitempty"`
+}
+
+// StatsCollector receives cache telemetry, implemented by pkg/metrics.
+type StatsCollector interface {
+	IncrementMoveCacheHits(tier string)
+}
+
+// Config controls the local LRU tier's size and TTL, and the Redis tier's
+// SETEX expiry.
+type Config struct {
+	LocalSize int           // max entries held in the in-process LRU
+	LocalTTL  time.Duration // how long a local entry stays fresh
+	RedisTTL  time.Duration // SETEX expiry for the Redis tier
+}
+
+// DefaultConfig returns cache settings suitable for a single api replica.
+func DefaultConfig() Config {
+	return Config{
+		LocalSize: 10000,
+		LocalTTL:  1 * time.Minute,
+		RedisTTL:  1 * time.Hour,
+	}
+}
+
+type localEntry struct {
+	key       string
+	value     Entry
+	expiresAt time.Time
+}
+
+// Cache is the local LRU tier, backed by Redis for Get's fallback lookup.
+// Both tiers are keyed by the digest Key returns.
+type Cache struct {
+	rdb    redis.UniversalClient
+	stats  StatsCollector
+	config Config
+
+	mu       sync.Mutex
+	ll       *list.List
+	elements map[string]*list.Element
+}
+
+// New creates a move cache against rdb, reporting hit-rate metrics through
+// stats.
+func New(rdb redis.UniversalClient, stats StatsCollector, config Config) *Cache {
+	return &Cache{
+		rdb:      rdb,
+		stats:    stats,
+		config:   config,
+		ll:       list.New(),
+		elements: make(map[string]*list.Element),
+	}
+}
+
+// Key canonicalizes (fen, elo, moveTimeMs) as "fen|elo|moveTimeMs" and
+// returns its SHA-256 hex digest, used as both the local map key and the
+// Redis key suffix.
+func Key(fen string, elo, moveTimeMs int) string {
+	canonical := fmt.Sprintf("%s|%d|%d", fen, elo, moveTimeMs)
+	sum := sha256.Sum256([]byte(canonical))
+	return hex.EncodeToString(sum[:])
+}
+
+// Get consults the local LRU, then Redis, returning the tier that served
+// the hit ("local" or "redis") so callers can log which tier answered. A
+// Redis hit is promoted into the local tier before returning.
+func (c *Cache) Get(ctx context.Context, key string) (Entry, string, bool) {
+	if entry, ok := c.getLocal(key); ok {
+		c.stats.IncrementMoveCacheHits("local")
+		return entry, "local", true
+	}
+
+	data, err := c.rdb.Get(ctx, keys.MoveCacheKey(key)).Result()
+	if err != nil {
+		return Entry{}, "", false
+	}
+	var entry Entry
+	if err := json.Unmarshal([]byte(data), &entry); err != nil {
+		return Entry{}, "", false
+	}
+
+	c.putLocal(key, entry)
+	c.stats.IncrementMoveCacheHits("redis")
+	return entry, "redis", true
+}
+
+// PutLocal populates only the local tier with entry for key. api calls this
+// once a queued job completes, since the Redis tier is already populated by
+// the worker that ran it (see SetRedis).
+func (c *Cache) PutLocal(key string, entry Entry) {
+	c.putLocal(key, entry)
+}
+
+// Invalidate removes key from both tiers.
+func (c *Cache) Invalidate(ctx context.Context, key string) error {
+	c.mu.Lock()
+	if el, ok := c.elements[key]; ok {
+		c.ll.Remove(el)
+		delete(c.elements, key)
+	}
+	c.mu.Unlock()
+
+	return c.rdb.Del(ctx, keys.MoveCacheKey(key)).Err()
+}
+
+func (c *Cache) getLocal(key string) (Entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.elements[key]
+	if !ok {
+		return Entry{}, false
+	}
+	le := el.Value.(*localEntry)
+	if time.Now().After(le.expiresAt) {
+		c.ll.Remove(el)
+		delete(c.elements, key)
+		return Entry{}, false
+	}
+	c.ll.MoveToFront(el)
+	return le.value, true
+}
+
+func (c *Cache) putLocal(key string, value Entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.elements[key]; ok {
+		le := el.Value.(*localEntry)
+		le.value = value
+		le.expiresAt = time.Now().Add(c.config.LocalTTL)
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	el := c.ll.PushFront(&localEntry{
+		key:       key,
+		value:     value,
+		expiresAt: time.Now().Add(c.config.LocalTTL),
+	})
+	c.elements[key] = el
+
+	if c.ll.Len() > c.config.LocalSize {
+		c.evictOldest()
+	}
+}
+
+func (c *Cache) evictOldest() {
+	el := c.ll.Back()
+	if el == nil {
+		return
+	}
+	c.ll.Remove(el)
+	le := el.Value.(*localEntry)
+	delete(c.elements, le.key)
+}
+
+// SetRedis SETEXes entry under key's move cache key with the given TTL,
+// independent of any Cache's local tier. The worker calls this directly on
+// job completion, since it holds no local tier of its own to populate.
+func SetRedis(ctx context.Context, rdb redis.UniversalClient, key string, entry Entry, ttl time.Duration) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("movecache: marshal entry: %w", err)
+	}
+	return rdb.SetEX(ctx, keys.MoveCacheKey(key), data, ttl).Err()
+}
@@ -0,0 +1,235 @@
+// Package redisconn builds a redis.UniversalClient from environment-driven
+// configuration instead of the api and worker each hardcoding
+// redis.NewClient against a single address. REDIS_MODE selects the
+// topology - single node, Sentinel, or Cluster - so HA deployments just
+// work: callers keep using the same Ping/XAdd/XLen/Publish/Subscribe calls
+// against the returned client regardless of which one backs it.
+package redisconn
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Mode selects which Redis topology New connects to.
+type Mode string
+
+const (
+	ModeSingle   Mode = "single"
+	ModeSentinel Mode = "sentinel"
+	ModeCluster  Mode = "cluster"
+)
+
+// Config holds everything New needs to build a redis.UniversalClient,
+// populated from REDIS_* environment variables by ConfigFromEnv.
+type Config struct {
+	Mode Mode
+
+	// Single-node
+	Addr string
+
+	// Sentinel
+	SentinelAddrs    []string
+	SentinelMaster   string
+	SentinelPassword string
+
+	// Cluster
+	ClusterAddrs []string
+
+	Password string
+
+	TLSEnabled            bool
+	TLSInsecureSkipVerify bool
+
+	PoolSize     int
+	MinIdleConns int
+	IdleTimeout  time.Duration
+
+	// OnFailover is called with the Sentinel master name only when the
+	// master address FailoverOptions.OnConnect resolves actually changes
+	// from the last one observed - not on every dial, since OnConnect also
+	// fires for ordinary pool warm-up/growth and idle-conn recycling - so
+	// callers can drive the same state-change handling (logging, metrics)
+	// as the Redis circuit breaker's OnStateChange.
+	OnFailover func(masterName string)
+}
+
+// ConfigFromEnv reads REDIS_MODE and its mode-specific settings, defaulting
+// to a single node at REDIS_ADDR (or "redis:6379") so deployments that set
+// neither variable keep working unchanged.
+func ConfigFromEnv() Config {
+	return Config{
+		Mode:                  Mode(getenv("REDIS_MODE", string(ModeSingle))),
+		Addr:                  getenv("REDIS_ADDR", "redis:6379"),
+		SentinelAddrs:         splitCSV(os.Getenv("REDIS_SENTINEL_ADDRS")),
+		SentinelMaster:        os.Getenv("REDIS_SENTINEL_MASTER"),
+		SentinelPassword:      os.Getenv("REDIS_SENTINEL_PASSWORD"),
+		ClusterAddrs:          splitCSV(os.Getenv("REDIS_CLUSTER_ADDRS")),
+		Password:              os.Getenv("REDIS_PASSWORD"),
+		TLSEnabled:            os.Getenv("REDIS_TLS_ENABLED") == "true",
+		TLSInsecureSkipVerify: os.Getenv("REDIS_TLS_INSECURE_SKIP_VERIFY") == "true",
+		PoolSize:              getenvInt("REDIS_POOL_SIZE", 0),
+		MinIdleConns:          getenvInt("REDIS_MIN_IDLE_CONNS", 0),
+		IdleTimeout:           getenvDuration("REDIS_IDLE_TIMEOUT", 5*time.Minute),
+	}
+}
+
+// New builds a redis.UniversalClient for cfg.Mode: a *redis.Client for
+// ModeSingle, a Sentinel-backed failover *redis.Client (NewFailoverClient)
+// for ModeSentinel, or a *redis.ClusterClient sharded across cfg.ClusterAddrs
+// for ModeCluster. All three satisfy redis.UniversalClient, so main()'s
+// rdb.Ping/XAdd/XLen/Publish/Subscribe calls don't change.
+func New(cfg Config) (redis.UniversalClient, error) {
+	var tlsConfig *tls.Config
+	if cfg.TLSEnabled {
+		tlsConfig = &tls.Config{InsecureSkipVerify: cfg.TLSInsecureSkipVerify}
+	}
+
+	switch cfg.Mode {
+	case ModeSingle, "":
+		return redis.NewClient(&redis.Options{
+			Addr:         cfg.Addr,
+			Password:     cfg.Password,
+			TLSConfig:    tlsConfig,
+			PoolSize:     cfg.PoolSize,
+			MinIdleConns: cfg.MinIdleConns,
+			IdleTimeout:  cfg.IdleTimeout,
+		}), nil
+
+	case ModeSentinel:
+		if len(cfg.SentinelAddrs) == 0 || cfg.SentinelMaster == "" {
+			return nil, fmt.Errorf("redisconn: sentinel mode requires REDIS_SENTINEL_ADDRS and REDIS_SENTINEL_MASTER")
+		}
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       cfg.SentinelMaster,
+			SentinelAddrs:    cfg.SentinelAddrs,
+			SentinelPassword: cfg.SentinelPassword,
+			Password:         cfg.Password,
+			TLSConfig:        tlsConfig,
+			PoolSize:         cfg.PoolSize,
+			MinIdleConns:     cfg.MinIdleConns,
+			IdleTimeout:      cfg.IdleTimeout,
+			OnConnect:        onFailoverConnect(cfg),
+		}), nil
+
+	case ModeCluster:
+		if len(cfg.ClusterAddrs) == 0 {
+			return nil, fmt.Errorf("redisconn: cluster mode requires REDIS_CLUSTER_ADDRS")
+		}
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:        cfg.ClusterAddrs,
+			Password:     cfg.Password,
+			TLSConfig:    tlsConfig,
+			PoolSize:     cfg.PoolSize,
+			MinIdleConns: cfg.MinIdleConns,
+			IdleTimeout:  cfg.IdleTimeout,
+		}), nil
+
+	default:
+		return nil, fmt.Errorf("redisconn: unknown REDIS_MODE %q (want %q, %q, or %q)", cfg.Mode, ModeSingle, ModeSentinel, ModeCluster)
+	}
+}
+
+// onFailoverConnect wraps an optional OnFailover callback as the
+// FailoverOptions.OnConnect hook go-redis runs on every new connection -
+// including ordinary pool warm-up/growth and idle-conn recycling, not just
+// genuine failovers. It resolves the current master address through
+// Sentinel on each dial and only calls onFailover when that address differs
+// from the last one observed, so a real promotion still fires the callback
+// but process startup and routine pool churn don't.
+func onFailoverConnect(cfg Config) func(ctx context.Context, cn *redis.Conn) error {
+	if cfg.OnFailover == nil {
+		return nil
+	}
+
+	var mu sync.Mutex
+	var lastAddr string
+
+	return func(ctx context.Context, cn *redis.Conn) error {
+		addr := resolveMasterAddr(ctx, cfg)
+		if addr == "" {
+			return nil
+		}
+
+		mu.Lock()
+		changed := lastAddr != "" && lastAddr != addr
+		lastAddr = addr
+		mu.Unlock()
+
+		if changed {
+			cfg.OnFailover(cfg.SentinelMaster)
+		}
+		return nil
+	}
+}
+
+// resolveMasterAddr asks each configured Sentinel in turn for the address it
+// currently believes is master for cfg.SentinelMaster, returning the first
+// one that answers ("" if none do, e.g. all Sentinels are unreachable).
+func resolveMasterAddr(ctx context.Context, cfg Config) string {
+	for _, sentinelAddr := range cfg.SentinelAddrs {
+		sentinel := redis.NewSentinelClient(&redis.Options{
+			Addr:     sentinelAddr,
+			Password: cfg.SentinelPassword,
+		})
+		parts, err := sentinel.GetMasterAddrByName(ctx, cfg.SentinelMaster).Result()
+		sentinel.Close()
+		if err == nil && len(parts) == 2 {
+			return parts[0] + ":" + parts[1]
+		}
+	}
+	return ""
+}
+
+func getenv(k, def string) string {
+	if v := os.Getenv(k); v != "" {
+		return v
+	}
+	return def
+}
+
+func getenvInt(k string, def int) int {
+	v := os.Getenv(k)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return def
+	}
+	return n
+}
+
+func getenvDuration(k string, def time.Duration) time.Duration {
+	v := os.Getenv(k)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return def
+	}
+	return d
+}
+
+func splitCSV(s string) []string {
+	if s == "" {
+		return nil
+	}
+	parts := strings.Split(s, ",")
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
@@ -0,0 +1,141 @@
+// Package resilience composes individual resilience policies (retry, circuit
+// breaker, timeout, bulkhead, fallback) into a single chain, so call sites
+// that today manually nest e.g. redisCircuitBreaker.Call(func() error {
+// return retry.WithRetry(...) }) can build and Execute one Policy instead.
+// Composition follows failsafe-go's outer-to-inner execution model: the
+// first Policy passed to NewPolicy wraps every Policy after it, so it also
+// sees their errors (a FallbackPolicy wrapping a CircuitBreakerPolicy sees
+// circuitbreaker.ErrCircuitOpen).
+package resilience
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"stockfish-scale/pkg/circuitbreaker"
+	"stockfish-scale/pkg/retry"
+)
+
+// Policy wraps an inner executor with some resilience behavior. Wrap must
+// call next itself (with whatever context it chooses) to invoke the rest of
+// the chain; a Wrap that never calls next (e.g. a tripped circuit breaker, or
+// a bulkhead that's full) simply short-circuits it.
+type Policy interface {
+	Wrap(next func(ctx context.Context) error) func(ctx context.Context) error
+}
+
+// Composed is a chain of Policies built by NewPolicy.
+type Composed struct {
+	policies []Policy
+}
+
+// NewPolicy composes policies outer-to-inner: policies[0].Wrap wraps
+// policies[1].Wrap wraps ... wraps the fn eventually passed to Execute.
+func NewPolicy(policies ...Policy) *Composed {
+	return &Composed{policies: policies}
+}
+
+// Execute runs fn through every composed Policy, outer-to-inner.
+func (c *Composed) Execute(ctx context.Context, fn func(ctx context.Context) error) error {
+	chain := fn
+	for i := len(c.policies) - 1; i >= 0; i-- {
+		chain = c.policies[i].Wrap(chain)
+	}
+	return chain(ctx)
+}
+
+// RetryPolicy wraps next with retry.WithRetry.
+type RetryPolicy struct {
+	Config retry.Config
+}
+
+func (p RetryPolicy) Wrap(next func(ctx context.Context) error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		return retry.WithRetry(ctx, p.Config, next)
+	}
+}
+
+// CircuitBreakerPolicy wraps next with Breaker.Call.
+type CircuitBreakerPolicy struct {
+	Breaker *circuitbreaker.CircuitBreaker
+}
+
+func (p CircuitBreakerPolicy) Wrap(next func(ctx context.Context) error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		return p.Breaker.Call(func() error {
+			return next(ctx)
+		})
+	}
+}
+
+// TimeoutPolicy derives a context.WithTimeout for next on every call. A
+// zero Timeout disables it (next runs with ctx unchanged).
+type TimeoutPolicy struct {
+	Timeout time.Duration
+}
+
+func (p TimeoutPolicy) Wrap(next func(ctx context.Context) error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if p.Timeout <= 0 {
+			return next(ctx)
+		}
+		tctx, cancel := context.WithTimeout(ctx, p.Timeout)
+		defer cancel()
+		return next(tctx)
+	}
+}
+
+// ErrBulkheadFull is returned by BulkheadPolicy when MaxConcurrent calls are
+// already in flight.
+var ErrBulkheadFull = errors.New("resilience: bulkhead is full")
+
+// BulkheadPolicy caps the number of concurrent in-flight calls at
+// MaxConcurrent, returning ErrBulkheadFull instead of queuing once that cap
+// is reached - a weighted semaphore sized to one weight unit per call.
+type BulkheadPolicy struct {
+	MaxConcurrent int
+
+	initOnce sync.Once
+	sem      chan struct{}
+}
+
+func (p *BulkheadPolicy) init() {
+	p.initOnce.Do(func() {
+		p.sem = make(chan struct{}, p.MaxConcurrent)
+	})
+}
+
+func (p *BulkheadPolicy) Wrap(next func(ctx context.Context) error) func(ctx context.Context) error {
+	p.init()
+	return func(ctx context.Context) error {
+		select {
+		case p.sem <- struct{}{}:
+		default:
+			return ErrBulkheadFull
+		}
+		defer func() { <-p.sem }()
+		return next(ctx)
+	}
+}
+
+// FallbackPolicy runs OnFailure when next returns a non-nil error - e.g. the
+// inner chain's retries are exhausted, or a wrapped CircuitBreakerPolicy
+// reports circuitbreaker.ErrCircuitOpen. OnFailure returns nil to recover
+// (having stashed whatever cached/default value the caller's fn closure
+// should see, the same closure convention Policy's func(ctx) error signature
+// already relies on) or a (possibly wrapped) error to leave the call failed.
+type FallbackPolicy struct {
+	OnFailure func(err error) error
+}
+
+func (p FallbackPolicy) Wrap(next func(ctx context.Context) error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		err := next(ctx)
+		if err == nil {
+			return nil
+		}
+		return p.OnFailure(err)
+	}
+}
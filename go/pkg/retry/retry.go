@@ -2,59 +2,254 @@ package retry
 
 import (
 	"context"
+	cryptorand "crypto/rand"
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"math"
 	"math/rand"
+	"sync"
 	"time"
 )
 
+// Strategy selects the algorithm nextBackoff uses to turn an attempt number
+// (and, for StrategyDecorrelatedJitter, the previous delay) into a backoff
+// duration.
+type Strategy int
+
+const (
+	// StrategyExponentialJitter is today's behavior: delay =
+	// min(InitialDelay*Multiplier^attempt, MaxDelay), then +/-JitterPercent
+	// symmetric jitter. The zero value, so existing Configs are unaffected.
+	StrategyExponentialJitter Strategy = iota
+	// StrategyFullJitter picks delay = rand(0, min(MaxDelay,
+	// InitialDelay*Multiplier^attempt)) - AWS's "full jitter", which spreads
+	// retries across the whole interval instead of clustering them around
+	// the exponential curve the way symmetric jitter still does under load.
+	StrategyFullJitter
+	// StrategyDecorrelatedJitter is AWS's decorrelated-jitter algorithm:
+	// delay = min(MaxDelay, rand(InitialDelay, prevDelay*3)), seeded with
+	// InitialDelay on attempt 0. Threading the previous delay through
+	// decorrelates successive retry waves even further than full jitter.
+	StrategyDecorrelatedJitter
+)
+
 // Config holds retry configuration
 type Config struct {
 	MaxAttempts   int           // Maximum number of retry attempts
 	InitialDelay  time.Duration // Initial delay before first retry
 	MaxDelay      time.Duration // Maximum delay between retries
 	Multiplier    float64       // Backoff multiplier
-	JitterPercent float64       // Jitter percentage (0.0 to 1.0)
+	JitterPercent float64       // Jitter percentage (0.0 to 1.0), used by StrategyExponentialJitter only
+	Strategy      Strategy      // Backoff algorithm; zero value is StrategyExponentialJitter
 	OnRetry       func(attempt int, delay time.Duration, err error) // Optional callback for retry attempts
+
+	// IsRetryable decides whether err should trigger another attempt. The
+	// default (nil) retries every error except context.Canceled,
+	// context.DeadlineExceeded, and any error implementing
+	// `interface{ Retryable() bool }` that returns false - see
+	// MarkNonRetryable for a convenient way to produce the latter.
+	IsRetryable func(err error) bool
+
+	// MaxElapsed bounds the total time spent across attempts and their backoff
+	// sleeps. Zero means unbounded (today's behavior). If the next backoff
+	// would push the elapsed time past MaxElapsed, Do/WithRetry return early
+	// instead of sleeping through a wait that would blow the budget anyway.
+	MaxElapsed time.Duration
+
+	// PerAttemptTimeout, if set, bounds how long Do/WithRetry wait for a
+	// single invocation of fn before counting it as a failed attempt and
+	// moving on to the next one - the "push-timeout" idea from ingester
+	// circuit breakers, so a slow call can't block the caller forever. Each
+	// attempt runs with a context.WithTimeout derived from PerAttemptTimeout,
+	// so an fn that honors ctx cancellation (a context-aware Redis call, a
+	// net.Conn with a context-driven deadline, ...) actually stops instead
+	// of leaking a goroutine blocked on the underlying I/O.
+	PerAttemptTimeout time.Duration
+}
+
+// ErrNonRetryable wraps the final error returned when IsRetryable stops the
+// retry loop early instead of exhausting MaxAttempts.
+var ErrNonRetryable = errors.New("retry: error is not retryable")
+
+// ErrAttemptTimeout is the error recorded for an attempt that didn't complete
+// within Config.PerAttemptTimeout.
+var ErrAttemptTimeout = errors.New("retry: attempt exceeded PerAttemptTimeout")
+
+// MarkNonRetryable wraps err so the default IsRetryable policy (and any
+// custom IsRetryable that checks for the same interface) treats it as
+// non-retryable, without callers having to declare their own error type.
+func MarkNonRetryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return nonRetryableError{err}
+}
+
+type nonRetryableError struct{ error }
+
+func (e nonRetryableError) Unwrap() error   { return e.error }
+func (e nonRetryableError) Retryable() bool { return false }
+
+// backoffRand is a dedicated *rand.Rand for calculateBackoff/nextBackoff,
+// seeded once from crypto/rand at package init, guarded by its own mutex
+// instead of going through math/rand's global source - with many workers
+// retrying concurrently, that global source's lock becomes a hot spot.
+var (
+	backoffRandMu sync.Mutex
+	backoffRand   = rand.New(rand.NewSource(cryptoSeed()))
+)
+
+// cryptoSeed reads an int64 seed from crypto/rand, falling back to the
+// current time if that ever fails (e.g. a sandboxed environment with no
+// entropy source available).
+func cryptoSeed() int64 {
+	var b [8]byte
+	if _, err := cryptorand.Read(b[:]); err != nil {
+		return time.Now().UnixNano()
+	}
+	return int64(binary.LittleEndian.Uint64(b[:]))
+}
+
+func randFloat64() float64 {
+	backoffRandMu.Lock()
+	defer backoffRandMu.Unlock()
+	return backoffRand.Float64()
+}
+
+// retryable reports whether cfg allows retrying err: cfg.IsRetryable if set,
+// otherwise the default policy described on Config.IsRetryable.
+func retryable(cfg Config, err error) bool {
+	if cfg.IsRetryable != nil {
+		return cfg.IsRetryable(err)
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var r interface{ Retryable() bool }
+	if errors.As(err, &r) {
+		return r.Retryable()
+	}
+	return true
 }
 
 // WithRetry executes the given function with retry logic and exponential backoff
-// Requirements 4.1, 4.2, 4.5, 4.7: exponential backoff with jitter
-func WithRetry(ctx context.Context, cfg Config, fn func() error) error {
+// Requirements 4.1, 4.2, 4.5, 4.7: exponential backoff with jitter. It is a thin
+// wrapper over the generic Do for callers that don't need a typed result.
+func WithRetry(ctx context.Context, cfg Config, fn func(ctx context.Context) error) error {
+	_, err := Do(ctx, cfg, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+	return err
+}
+
+// Do executes fn with WithRetry's retry logic and exponential backoff, returning
+// fn's typed result directly instead of callers having to close over a variable
+// to smuggle a result out of WithRetry's func() error (which also hides the zero
+// value on failure) - useful in the worker path, where a Stockfish call returns
+// an evaluation struct rather than nothing. fn receives the per-attempt context
+// so it can observe cancellation from PerAttemptTimeout (or ctx itself).
+func Do[T any](ctx context.Context, cfg Config, fn func(ctx context.Context) (T, error)) (T, error) {
+	var zero, result T
 	var lastErr error
-	
+	var prevDelay time.Duration
+	start := time.Now()
+
 	for attempt := 0; attempt < cfg.MaxAttempts; attempt++ {
-		// Execute the function
-		err := fn()
+		// Execute the function, bounded by PerAttemptTimeout if configured
+		var err error
+		result, err = callWithTimeout(ctx, cfg, fn)
 		if err == nil {
-			return nil
+			return result, nil
 		}
-		
+
 		lastErr = err
-		
+
+		if !retryable(cfg, err) {
+			return zero, fmt.Errorf("%w: %v", ErrNonRetryable, err)
+		}
+
 		// Don't sleep after the last attempt
 		if attempt == cfg.MaxAttempts-1 {
 			break
 		}
-		
-		// Calculate backoff delay with exponential backoff
-		delay := calculateBackoff(cfg, attempt)
-		
+
+		// Calculate the next backoff delay per cfg.Strategy
+		delay := nextBackoff(cfg, attempt, prevDelay)
+		prevDelay = delay
+
+		// Stop short of sleeping through a wait that would blow MaxElapsed
+		if cfg.MaxElapsed > 0 && time.Since(start)+delay > cfg.MaxElapsed {
+			return zero, fmt.Errorf("all retry attempts exhausted: %w", lastErr)
+		}
+
 		// Call retry callback if provided (Requirement 4.7: Log each retry attempt)
 		if cfg.OnRetry != nil {
 			cfg.OnRetry(attempt+1, delay, err)
 		}
-		
+
 		// Check if context is cancelled
 		select {
 		case <-ctx.Done():
-			return fmt.Errorf("retry cancelled: %w", ctx.Err())
+			return zero, fmt.Errorf("retry cancelled: %w", ctx.Err())
 		case <-time.After(delay):
 			// Continue to next attempt
 		}
 	}
-	
-	return fmt.Errorf("all retry attempts exhausted: %w", lastErr)
+
+	return zero, fmt.Errorf("all retry attempts exhausted: %w", lastErr)
+}
+
+// callWithTimeout invokes fn with a context derived from ctx via
+// context.WithTimeout(ctx, cfg.PerAttemptTimeout) (ctx unchanged when
+// PerAttemptTimeout is unset), returning ErrAttemptTimeout if fn doesn't
+// complete before that deadline. The derived context is cancelled either
+// way (the deferred cancel), so an fn that threads ctx through to its
+// underlying I/O (a context-aware Redis call, a net.Conn read bound to
+// ctx's deadline, ...) actually stops instead of running on in the
+// background after callWithTimeout gives up on it.
+func callWithTimeout[T any](ctx context.Context, cfg Config, fn func(ctx context.Context) (T, error)) (T, error) {
+	if cfg.PerAttemptTimeout <= 0 {
+		return fn(ctx)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, cfg.PerAttemptTimeout)
+	defer cancel()
+
+	type outcome struct {
+		result T
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		result, err := fn(attemptCtx)
+		done <- outcome{result, err}
+	}()
+
+	select {
+	case o := <-done:
+		return o.result, o.err
+	case <-attemptCtx.Done():
+		var zero T
+		if ctx.Err() != nil {
+			return zero, ctx.Err()
+		}
+		return zero, ErrAttemptTimeout
+	}
+}
+
+// nextBackoff dispatches to the backoff algorithm cfg.Strategy selects,
+// threading prevDelay through for StrategyDecorrelatedJitter (the other two
+// strategies are memoryless and ignore it).
+func nextBackoff(cfg Config, attempt int, prevDelay time.Duration) time.Duration {
+	switch cfg.Strategy {
+	case StrategyFullJitter:
+		return fullJitterBackoff(cfg, attempt)
+	case StrategyDecorrelatedJitter:
+		return decorrelatedJitterBackoff(cfg, attempt, prevDelay)
+	default:
+		return calculateBackoff(cfg, attempt)
+	}
 }
 
 // calculateBackoff calculates the backoff delay with exponential backoff and jitter
@@ -62,24 +257,59 @@ func WithRetry(ctx context.Context, cfg Config, fn func() error) error {
 // Then apply jitter: delay * (1 + random(-jitterPercent, +jitterPercent))
 func calculateBackoff(cfg Config, attempt int) time.Duration {
 	// Calculate exponential backoff
-	delay := float64(cfg.InitialDelay) * math.Pow(cfg.Multiplier, float64(attempt))
-	
-	// Cap at max delay
-	if delay > float64(cfg.MaxDelay) {
-		delay = float64(cfg.MaxDelay)
-	}
-	
+	delay := exponentialCap(cfg, attempt)
+
 	// Apply jitter if configured
 	if cfg.JitterPercent > 0 {
-		jitter := delay * cfg.JitterPercent * (2*rand.Float64() - 1) // Random between -jitterPercent and +jitterPercent
+		jitter := delay * cfg.JitterPercent * (2*randFloat64() - 1) // Random between -jitterPercent and +jitterPercent
 		delay += jitter
-		
+
 		// Ensure delay is not negative
 		if delay < 0 {
 			delay = float64(cfg.InitialDelay)
 		}
 	}
-	
+
+	return time.Duration(delay)
+}
+
+// exponentialCap is min(InitialDelay*Multiplier^attempt, MaxDelay), the curve
+// both StrategyExponentialJitter and StrategyFullJitter jitter around.
+func exponentialCap(cfg Config, attempt int) float64 {
+	delay := float64(cfg.InitialDelay) * math.Pow(cfg.Multiplier, float64(attempt))
+	if delay > float64(cfg.MaxDelay) {
+		delay = float64(cfg.MaxDelay)
+	}
+	return delay
+}
+
+// fullJitterBackoff picks delay = rand(0, exponentialCap(cfg, attempt)).
+func fullJitterBackoff(cfg Config, attempt int) time.Duration {
+	capDelay := exponentialCap(cfg, attempt)
+	if capDelay <= 0 {
+		return 0
+	}
+	return time.Duration(randFloat64() * capDelay)
+}
+
+// decorrelatedJitterBackoff implements AWS's decorrelated-jitter algorithm:
+// delay = min(MaxDelay, rand(InitialDelay, prevDelay*3)), seeded with
+// InitialDelay on attempt 0 (or if prevDelay is unset).
+func decorrelatedJitterBackoff(cfg Config, attempt int, prevDelay time.Duration) time.Duration {
+	if attempt == 0 || prevDelay <= 0 {
+		return cfg.InitialDelay
+	}
+
+	lo := float64(cfg.InitialDelay)
+	hi := float64(prevDelay) * 3
+	if hi < lo {
+		hi = lo
+	}
+
+	delay := lo + randFloat64()*(hi-lo)
+	if cfg.MaxDelay > 0 && delay > float64(cfg.MaxDelay) {
+		delay = float64(cfg.MaxDelay)
+	}
 	return time.Duration(delay)
 }
 
@@ -119,7 +349,22 @@ func RedisResultRetryConfig() Config {
 	}
 }
 
-// GetBackoffDuration returns the backoff duration for a given attempt (useful for logging)
+// GetBackoffDuration returns the StrategyExponentialJitter backoff duration
+// for a given attempt (useful for logging), regardless of cfg.Strategy.
 func GetBackoffDuration(cfg Config, attempt int) time.Duration {
 	return calculateBackoff(cfg, attempt)
 }
+
+// GetBackoffSequence previews the first n backoff delays cfg.Strategy would
+// produce, threading each step's delay into the next the same way Do/WithRetry
+// do for StrategyDecorrelatedJitter.
+func GetBackoffSequence(cfg Config, n int) []time.Duration {
+	seq := make([]time.Duration, 0, n)
+	var prevDelay time.Duration
+	for attempt := 0; attempt < n; attempt++ {
+		delay := nextBackoff(cfg, attempt, prevDelay)
+		seq = append(seq, delay)
+		prevDelay = delay
+	}
+	return seq
+}
@@ -0,0 +1,182 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDoRetriesUntilSuccess(t *testing.T) {
+	cfg := Config{MaxAttempts: 3, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond, Multiplier: 1}
+
+	attempts := 0
+	got, err := Do(context.Background(), cfg, func(ctx context.Context) (int, error) {
+		attempts++
+		if attempts < 3 {
+			return 0, errors.New("transient")
+		}
+		return 7, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 7 {
+		t.Fatalf("got %d, want 7", got)
+	}
+	if attempts != 3 {
+		t.Fatalf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestDoStopsOnNonRetryableError(t *testing.T) {
+	cfg := Config{MaxAttempts: 5, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond, Multiplier: 1}
+
+	attempts := 0
+	err := WithRetry(context.Background(), cfg, func(ctx context.Context) error {
+		attempts++
+		return MarkNonRetryable(errors.New("permanent"))
+	})
+	if !errors.Is(err, ErrNonRetryable) {
+		t.Fatalf("got %v, want ErrNonRetryable", err)
+	}
+	if attempts != 1 {
+		t.Fatalf("attempts = %d, want 1 (should not retry a non-retryable error)", attempts)
+	}
+}
+
+func TestDoRespectsContextCancellation(t *testing.T) {
+	cfg := Config{MaxAttempts: 5, InitialDelay: time.Hour, MaxDelay: time.Hour, Multiplier: 1}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	_, err := Do(ctx, cfg, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, errors.New("always fails")
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want context.Canceled", err)
+	}
+}
+
+func TestDoRespectsMaxElapsed(t *testing.T) {
+	cfg := Config{
+		MaxAttempts:  10,
+		InitialDelay: 50 * time.Millisecond,
+		MaxDelay:     50 * time.Millisecond,
+		Multiplier:   1,
+		MaxElapsed:   10 * time.Millisecond,
+	}
+
+	attempts := 0
+	start := time.Now()
+	_, err := Do(context.Background(), cfg, func(ctx context.Context) (struct{}, error) {
+		attempts++
+		return struct{}{}, errors.New("always fails")
+	})
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("expected an error once MaxElapsed is exceeded")
+	}
+	if attempts >= cfg.MaxAttempts {
+		t.Fatalf("attempts = %d, want fewer than MaxAttempts since MaxElapsed should cut the loop short", attempts)
+	}
+	if elapsed > cfg.InitialDelay*time.Duration(cfg.MaxAttempts) {
+		t.Fatalf("elapsed = %v, want well under what MaxAttempts*InitialDelay would take without MaxElapsed", elapsed)
+	}
+}
+
+func TestCallWithTimeoutTimesOutSlowAttempt(t *testing.T) {
+	cfg := Config{PerAttemptTimeout: 10 * time.Millisecond}
+
+	started := make(chan struct{})
+	result, err := callWithTimeout(context.Background(), cfg, func(ctx context.Context) (int, error) {
+		close(started)
+		<-ctx.Done()
+		return 1, nil
+	})
+	<-started
+
+	if !errors.Is(err, ErrAttemptTimeout) {
+		t.Fatalf("got %v, want ErrAttemptTimeout", err)
+	}
+	if result != 0 {
+		t.Fatalf("result = %d, want zero value on timeout", result)
+	}
+}
+
+func TestCallWithTimeoutCancelsAttemptContext(t *testing.T) {
+	cfg := Config{PerAttemptTimeout: 10 * time.Millisecond}
+
+	var sawCancellation error
+	done := make(chan struct{})
+	_, err := callWithTimeout(context.Background(), cfg, func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		sawCancellation = ctx.Err()
+		close(done)
+		return 0, ctx.Err()
+	})
+	if !errors.Is(err, ErrAttemptTimeout) {
+		t.Fatalf("got %v, want ErrAttemptTimeout", err)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("fn's context was never cancelled after PerAttemptTimeout elapsed")
+	}
+	if !errors.Is(sawCancellation, context.DeadlineExceeded) {
+		t.Fatalf("fn observed ctx.Err() = %v, want context.DeadlineExceeded", sawCancellation)
+	}
+}
+
+func TestFullJitterBackoffStaysWithinCap(t *testing.T) {
+	cfg := Config{InitialDelay: 10 * time.Millisecond, MaxDelay: time.Second, Multiplier: 2, Strategy: StrategyFullJitter}
+
+	for attempt := 0; attempt < 5; attempt++ {
+		capDelay := time.Duration(exponentialCap(cfg, attempt))
+		for i := 0; i < 20; i++ {
+			delay := nextBackoff(cfg, attempt, 0)
+			if delay < 0 || delay > capDelay {
+				t.Fatalf("attempt %d: delay %v out of [0, %v]", attempt, delay, capDelay)
+			}
+		}
+	}
+}
+
+func TestDecorrelatedJitterBackoffSeedsFromInitialDelay(t *testing.T) {
+	cfg := Config{InitialDelay: 10 * time.Millisecond, MaxDelay: time.Second, Strategy: StrategyDecorrelatedJitter}
+
+	if got := nextBackoff(cfg, 0, 0); got != cfg.InitialDelay {
+		t.Fatalf("attempt 0 delay = %v, want InitialDelay %v", got, cfg.InitialDelay)
+	}
+}
+
+func TestDecorrelatedJitterBackoffRespectsMaxDelay(t *testing.T) {
+	cfg := Config{InitialDelay: 10 * time.Millisecond, MaxDelay: 20 * time.Millisecond, Strategy: StrategyDecorrelatedJitter}
+
+	prev := cfg.InitialDelay
+	for i := 0; i < 50; i++ {
+		delay := nextBackoff(cfg, 1, prev)
+		if delay > cfg.MaxDelay {
+			t.Fatalf("delay %v exceeds MaxDelay %v", delay, cfg.MaxDelay)
+		}
+		prev = delay
+	}
+}
+
+func TestGetBackoffSequenceMatchesDosStepping(t *testing.T) {
+	cfg := Config{InitialDelay: 10 * time.Millisecond, MaxDelay: time.Second, Strategy: StrategyDecorrelatedJitter}
+
+	seq := GetBackoffSequence(cfg, 3)
+	if len(seq) != 3 {
+		t.Fatalf("len(seq) = %d, want 3", len(seq))
+	}
+	if seq[0] != cfg.InitialDelay {
+		t.Fatalf("seq[0] = %v, want InitialDelay %v", seq[0], cfg.InitialDelay)
+	}
+}
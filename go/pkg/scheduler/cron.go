@@ -0,0 +1,124 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxCronSearchWindow bounds how far into the future schedule.next will
+// search for a matching minute before giving up, so a spec that can never
+// match (e.g. "0 0 31 2 *") fails fast instead of looping forever.
+const maxCronSearchWindow = 4 * 365 * 24 * time.Hour
+
+// schedule is a parsed standard 5-field cron expression (minute hour
+// day-of-month month day-of-week), evaluated in UTC.
+type schedule struct {
+	minutes map[int]bool
+	hours   map[int]bool
+	doms    map[int]bool
+	months  map[int]bool
+	dows    map[int]bool
+}
+
+// parseCron parses a standard 5-field cron expression. Each field accepts
+// "*", a single value, a range "a-b", a step "*/n" or "a-b/n", or a
+// comma-separated list of those.
+func parseCron(spec string) (*schedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron spec must have 5 fields, got %d", len(fields))
+	}
+
+	minutes, err := parseCronField(fields[0], 0, 59)
+	if err != nil {
+		return nil, fmt.Errorf("minute field: %w", err)
+	}
+	hours, err := parseCronField(fields[1], 0, 23)
+	if err != nil {
+		return nil, fmt.Errorf("hour field: %w", err)
+	}
+	doms, err := parseCronField(fields[2], 1, 31)
+	if err != nil {
+		return nil, fmt.Errorf("day-of-month field: %w", err)
+	}
+	months, err := parseCronField(fields[3], 1, 12)
+	if err != nil {
+		return nil, fmt.Errorf("month field: %w", err)
+	}
+	dows, err := parseCronField(fields[4], 0, 6)
+	if err != nil {
+		return nil, fmt.Errorf("day-of-week field: %w", err)
+	}
+
+	return &schedule{minutes: minutes, hours: hours, doms: doms, months: months, dows: dows}, nil
+}
+
+// parseCronField expands one cron field into the set of values it matches
+// within [min, max].
+func parseCronField(field string, min, max int) (map[int]bool, error) {
+	values := make(map[int]bool)
+
+	for _, part := range strings.Split(field, ",") {
+		body, step := part, 1
+		if idx := strings.Index(part, "/"); idx != -1 {
+			body = part[:idx]
+			n, err := strconv.Atoi(part[idx+1:])
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid step in %q", part)
+			}
+			step = n
+		}
+
+		rangeStart, rangeEnd := min, max
+		if body != "*" {
+			if idx := strings.Index(body, "-"); idx != -1 {
+				start, errStart := strconv.Atoi(body[:idx])
+				end, errEnd := strconv.Atoi(body[idx+1:])
+				if errStart != nil || errEnd != nil {
+					return nil, fmt.Errorf("invalid range %q", body)
+				}
+				rangeStart, rangeEnd = start, end
+			} else {
+				n, err := strconv.Atoi(body)
+				if err != nil {
+					return nil, fmt.Errorf("invalid value %q", body)
+				}
+				rangeStart, rangeEnd = n, n
+			}
+		}
+
+		if rangeStart < min || rangeEnd > max || rangeStart > rangeEnd {
+			return nil, fmt.Errorf("value out of range [%d,%d] in %q", min, max, part)
+		}
+
+		for v := rangeStart; v <= rangeEnd; v += step {
+			values[v] = true
+		}
+	}
+
+	return values, nil
+}
+
+// matches reports whether t satisfies every field of the schedule.
+func (s *schedule) matches(t time.Time) bool {
+	return s.months[int(t.Month())] && s.doms[t.Day()] && s.dows[int(t.Weekday())] &&
+		s.hours[t.Hour()] && s.minutes[t.Minute()]
+}
+
+// next returns the earliest minute-aligned time strictly after after that
+// satisfies the schedule.
+func (s *schedule) next(after time.Time) (time.Time, error) {
+	t := after.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := after.Add(maxCronSearchWindow)
+
+	for t.Before(limit) {
+		if s.matches(t) {
+			return t, nil
+		}
+		t = t.Add(time.Minute)
+	}
+
+	return time.Time{}, fmt.Errorf("no matching fire time within %s", maxCronSearchWindow)
+}
@@ -0,0 +1,285 @@
+// Package scheduler runs periodic and one-shot delayed analysis jobs
+// alongside the existing one-shot move queue, modeled after Harbor
+// jobservice's period enqueuer: cron-spec'd job templates live in a Redis
+// sorted set keyed by next-fire time, and a single elected leader wakes up
+// on every tick, enqueues whichever templates are due as ordinary Job
+// entries on the shared job queue, and reschedules them.
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"stockfish-scale/pkg/keys"
+	"stockfish-scale/pkg/logging"
+	"stockfish-scale/pkg/metrics"
+)
+
+const (
+	// periodicSetKey holds every schedulable (not paused) template ID,
+	// scored by its next fire time in unix seconds.
+	periodicSetKey = "stockfish:periodic"
+
+	// allTemplatesSetKey holds every known template ID, including paused
+	// ones, so List can report templates that periodicSetKey has no entry
+	// for.
+	allTemplatesSetKey = "stockfish:periodic:ids"
+
+	// templateKeyPrefix prefixes the per-template state hash.
+	templateKeyPrefix = "stockfish:periodic:tpl:"
+
+	// leaderLeaseKey is held by whichever instance is currently running
+	// ticks, acquired with SET NX PX and renewed on every tick.
+	leaderLeaseKey = "stockfish:periodic:leader"
+
+	// maxCatchUpFires bounds how many missed fires a single tick will
+	// replay under MissedFiresCatchUp, so a template left paused for a long
+	// time can't flood the queue in one tick.
+	maxCatchUpFires = 50
+)
+
+// queuedJob mirrors the wire shape of api.Job / worker.Job, the JSON that
+// actually crosses Redis; pkg/scheduler has no compile-time dependency on
+// either service's package.
+type queuedJob struct {
+	JobID         string `json:"job_id"`
+	CorrelationID string `json:"correlation_id,omitempty"`
+	FEN           string `json:"fen"`
+	Elo           int    `json:"elo"`
+	MaxTime       int    `json:"max_time_ms"`
+	CreatedAt     string `json:"created_at,omitempty"`
+}
+
+// Manager owns the periodic job templates and the leader-elected enqueuer
+// loop that fires them.
+type Manager struct {
+	rdb          redis.UniversalClient
+	metricsCol   *metrics.MetricsCollector
+	logger       logging.Logger
+	instanceID   string
+	tickInterval time.Duration
+	leaseTTL     time.Duration
+	stopChan     chan struct{}
+	stoppedChan  chan struct{}
+}
+
+// NewManager creates a periodic job scheduler. instanceID identifies this
+// process in the leader lease so it can tell its own lease apart from
+// another instance's when renewing.
+func NewManager(rdb redis.UniversalClient, metricsCol *metrics.MetricsCollector, logger logging.Logger, instanceID string) *Manager {
+	return &Manager{
+		rdb:          rdb,
+		metricsCol:   metricsCol,
+		logger:       logger,
+		instanceID:   instanceID,
+		tickInterval: 5 * time.Second,
+		leaseTTL:     15 * time.Second,
+		stopChan:     make(chan struct{}),
+		stoppedChan:  make(chan struct{}),
+	}
+}
+
+// Start begins the tick loop in the background.
+func (m *Manager) Start() {
+	go m.run()
+}
+
+// Stop signals the tick loop to exit and waits for it to do so.
+func (m *Manager) Stop() {
+	close(m.stopChan)
+	<-m.stoppedChan
+}
+
+func (m *Manager) run() {
+	defer close(m.stoppedChan)
+
+	ticker := time.NewTicker(m.tickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stopChan:
+			return
+		case <-ticker.C:
+			tickCtx, cancel := context.WithTimeout(context.Background(), m.tickInterval)
+			if m.acquireLeadership(tickCtx) {
+				m.tick(tickCtx)
+			}
+			cancel()
+		}
+	}
+}
+
+// acquireLeadership claims the leader lease if it is free, or renews it if
+// this instance already holds it. Only the leader runs ticks, so a fleet of
+// API replicas doesn't all enqueue the same due template.
+func (m *Manager) acquireLeadership(ctx context.Context) bool {
+	ok, err := m.rdb.SetNX(ctx, leaderLeaseKey, m.instanceID, m.leaseTTL).Result()
+	if err != nil {
+		m.logger.Error("scheduler: leader lease acquire failed", err)
+		return false
+	}
+	if ok {
+		return true
+	}
+
+	holder, err := m.rdb.Get(ctx, leaderLeaseKey).Result()
+	if err != nil || holder != m.instanceID {
+		return false
+	}
+
+	if err := m.rdb.Expire(ctx, leaderLeaseKey, m.leaseTTL).Err(); err != nil {
+		m.logger.Error("scheduler: leader lease renewal failed", err)
+		return false
+	}
+	return true
+}
+
+// tick enqueues every template whose next fire time has passed.
+func (m *Manager) tick(ctx context.Context) {
+	now := time.Now()
+	due, err := m.rdb.ZRangeByScore(ctx, periodicSetKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(now.Unix(), 10),
+	}).Result()
+	if err != nil {
+		m.logger.Error("scheduler: failed to query due templates", err)
+		return
+	}
+
+	for _, id := range due {
+		m.fireTemplate(ctx, id, now)
+	}
+}
+
+// fireTemplate enqueues id's due fire(s) and reschedules it, or drops it
+// from the due-fire index if it turns out to be paused, deleted, or
+// unschedulable.
+func (m *Manager) fireTemplate(ctx context.Context, id string, now time.Time) {
+	tpl, err := m.getTemplate(ctx, id)
+	if err != nil {
+		m.logger.WithField("template_id", id).Error("scheduler: failed to load due template", err)
+		return
+	}
+	if tpl == nil || tpl.Paused {
+		// Stale entry: deleted or paused out of band since it was scheduled.
+		m.rdb.ZRem(ctx, periodicSetKey, id)
+		return
+	}
+
+	sched, oneShotAt, err := parseSpec(tpl.Spec)
+	if err != nil {
+		m.logger.WithField("template_id", id).Error("scheduler: template has an unschedulable spec, dropping it", err)
+		m.rdb.ZRem(ctx, periodicSetKey, id)
+		return
+	}
+
+	scheduledScore, err := m.rdb.ZScore(ctx, periodicSetKey, id).Result()
+	if err != nil {
+		m.logger.WithField("template_id", id).Error("scheduler: failed to read scheduled fire time", err)
+		return
+	}
+	m.metricsCol.RecordSchedulerDrift(now.Sub(time.Unix(int64(scheduledScore), 0)))
+
+	if sched == nil {
+		// One-shot delayed job: fire exactly once and never reschedule.
+		m.enqueueFire(ctx, tpl, oneShotAt.Unix())
+		m.markFired(ctx, tpl, now)
+		m.rdb.ZRem(ctx, periodicSetKey, id)
+		return
+	}
+
+	missedFires := collectMissedFires(sched, time.Unix(int64(scheduledScore), 0), now)
+	toFire := missedFires
+	if tpl.MissedFires == MissedFiresSkip && len(missedFires) > 1 {
+		skipped := len(missedFires) - 1
+		m.metricsCol.IncrementSchedulerSkippedFires(float64(skipped))
+		m.logger.WithFields(map[string]interface{}{
+			"template_id": id,
+			"skipped":     skipped,
+		}).Warn("scheduler: skipping missed fires")
+		toFire = missedFires[len(missedFires)-1:]
+	}
+
+	for _, fireAt := range toFire {
+		m.enqueueFire(ctx, tpl, fireAt.Unix())
+	}
+	m.markFired(ctx, tpl, now)
+
+	next, err := sched.next(now)
+	if err != nil {
+		m.logger.WithField("template_id", id).Error("scheduler: failed to compute next fire time, dropping template", err)
+		m.rdb.ZRem(ctx, periodicSetKey, id)
+		return
+	}
+	if err := m.scheduleNext(ctx, id, next); err != nil {
+		m.logger.WithField("template_id", id).Error("scheduler: failed to reschedule template", err)
+	}
+}
+
+// collectMissedFires returns every fire time from scheduledFire (inclusive,
+// already known to match the schedule) through upTo (inclusive), oldest
+// first. It stops early at maxCatchUpFires so a long-paused template can't
+// replay an unbounded backlog in one tick.
+func collectMissedFires(sched *schedule, scheduledFire, upTo time.Time) []time.Time {
+	fires := []time.Time{scheduledFire}
+	t := scheduledFire
+	for len(fires) < maxCatchUpFires {
+		next, err := sched.next(t)
+		if err != nil || next.After(upTo) {
+			break
+		}
+		fires = append(fires, next)
+		t = next
+	}
+	return fires
+}
+
+// markFired stamps tpl's last-fired-at timestamp.
+func (m *Manager) markFired(ctx context.Context, tpl *Template, firedAt time.Time) {
+	tpl.LastFiredAt = firedAt.Format(time.RFC3339Nano)
+	if err := m.rdb.HSet(ctx, templateKey(tpl.ID), "last_fired_at", tpl.LastFiredAt).Err(); err != nil {
+		m.logger.WithField("template_id", tpl.ID).Error("scheduler: failed to record last fired time", err)
+	}
+}
+
+// enqueueFire publishes one concrete Job for tpl onto the shared job queue,
+// with a freshly generated JobID and a CorrelationID that links back to the
+// template and the fire time it was created for.
+func (m *Manager) enqueueFire(ctx context.Context, tpl *Template, fireAt int64) {
+	jobID := fmt.Sprintf("job_%d_%d", time.Now().UnixNano(), tpl.Elo)
+	job := queuedJob{
+		JobID:         jobID,
+		CorrelationID: fmt.Sprintf("periodic-%s-%d", tpl.ID, fireAt),
+		FEN:           tpl.FEN,
+		Elo:           tpl.Elo,
+		MaxTime:       tpl.MaxTimeMs,
+		CreatedAt:     time.Now().Format(time.RFC3339Nano),
+	}
+
+	data, err := json.Marshal(job)
+	if err != nil {
+		m.logger.WithField("template_id", tpl.ID).Error("scheduler: failed to marshal periodic job", err)
+		return
+	}
+
+	queueKey := keys.JobsQueue(keys.ShardForJobID(jobID))
+	if err := m.rdb.XAdd(ctx, &redis.XAddArgs{
+		Stream: queueKey,
+		Values: map[string]interface{}{"job": data},
+	}).Err(); err != nil {
+		m.logger.WithField("template_id", tpl.ID).Error("scheduler: failed to enqueue periodic job", err)
+		return
+	}
+
+	m.logger.WithFields(map[string]interface{}{
+		"template_id": tpl.ID,
+		"job_id":      jobID,
+		"fire_at":     fireAt,
+	}).Info("scheduler: enqueued periodic job")
+}
@@ -0,0 +1,226 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// MissedFiresPolicy controls what a tick does when a template's next fire
+// time has fallen more than one fire behind, e.g. after the enqueuer was not
+// leader for a while.
+type MissedFiresPolicy string
+
+const (
+	// MissedFiresSkip drops every missed fire and enqueues only the most
+	// recent one, rescheduling from now.
+	MissedFiresSkip MissedFiresPolicy = "skip"
+	// MissedFiresCatchUp enqueues one job per missed fire, oldest first.
+	MissedFiresCatchUp MissedFiresPolicy = "catch_up"
+)
+
+// Template is a periodic (cron Spec) or one-shot (Spec is a single future
+// unix timestamp) job definition: everything handleJob needs to run the
+// analysis, plus the state that decides when it fires next.
+type Template struct {
+	ID          string            `json:"id"`
+	Spec        string            `json:"spec"`
+	FEN         string            `json:"fen"`
+	Elo         int               `json:"elo"`
+	MaxTimeMs   int               `json:"max_time_ms"`
+	Paused      bool              `json:"paused"`
+	MissedFires MissedFiresPolicy `json:"missed_fires_policy"`
+	CreatedAt   string            `json:"created_at,omitempty"`
+	LastFiredAt string            `json:"last_fired_at,omitempty"`
+}
+
+// parseSpec interprets tpl.Spec as either a 5-field cron expression (the
+// returned schedule is non-nil) or, failing that, a single unix-seconds
+// timestamp for a one-shot delayed job (the returned time is used instead).
+func parseSpec(spec string) (*schedule, time.Time, error) {
+	if ts, err := strconv.ParseInt(spec, 10, 64); err == nil {
+		return nil, time.Unix(ts, 0), nil
+	}
+
+	sched, err := parseCron(spec)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("spec %q is neither a unix timestamp nor a valid cron expression: %w", spec, err)
+	}
+	return sched, time.Time{}, nil
+}
+
+// templateKey returns the per-template hash key.
+func templateKey(id string) string {
+	return templateKeyPrefix + id
+}
+
+// CreateTemplate validates tpl, computes its first fire time, and persists
+// it. A paused template is stored but left out of the due-fire index until
+// SetPaused resumes it.
+func (m *Manager) CreateTemplate(ctx context.Context, tpl *Template) error {
+	if tpl.ID == "" {
+		return fmt.Errorf("scheduler: template ID is required")
+	}
+	if tpl.MissedFires == "" {
+		tpl.MissedFires = MissedFiresSkip
+	}
+
+	next, err := firstFireTime(tpl.Spec)
+	if err != nil {
+		return fmt.Errorf("scheduler: template %s: %w", tpl.ID, err)
+	}
+
+	tpl.CreatedAt = time.Now().Format(time.RFC3339Nano)
+
+	if err := m.saveTemplate(ctx, tpl); err != nil {
+		return err
+	}
+	if err := m.rdb.SAdd(ctx, allTemplatesSetKey, tpl.ID).Err(); err != nil {
+		return fmt.Errorf("scheduler: index template %s: %w", tpl.ID, err)
+	}
+
+	if tpl.Paused {
+		return nil
+	}
+	return m.scheduleNext(ctx, tpl.ID, next)
+}
+
+// GetTemplate returns tpl.ID's stored template, or nil if it does not exist.
+func (m *Manager) GetTemplate(ctx context.Context, id string) (*Template, error) {
+	return m.getTemplate(ctx, id)
+}
+
+// ListTemplates returns every known template regardless of paused state.
+func (m *Manager) ListTemplates(ctx context.Context) ([]*Template, error) {
+	ids, err := m.rdb.SMembers(ctx, allTemplatesSetKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("scheduler: list templates: %w", err)
+	}
+
+	templates := make([]*Template, 0, len(ids))
+	for _, id := range ids {
+		tpl, err := m.getTemplate(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("scheduler: load template %s: %w", id, err)
+		}
+		if tpl != nil {
+			templates = append(templates, tpl)
+		}
+	}
+	return templates, nil
+}
+
+// DeleteTemplate removes tpl.ID from the due-fire index, the all-templates
+// index, and its hash. These are three independently-named keys, so this
+// is a plain (non-transactional) pipeline rather than TxPipeline: a
+// MULTI/EXEC across keys that don't share a hash tag is rejected by Redis
+// Cluster with CROSSSLOT.
+func (m *Manager) DeleteTemplate(ctx context.Context, id string) error {
+	pipe := m.rdb.Pipeline()
+	pipe.ZRem(ctx, periodicSetKey, id)
+	pipe.SRem(ctx, allTemplatesSetKey, id)
+	pipe.Del(ctx, templateKey(id))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("scheduler: delete template %s: %w", id, err)
+	}
+	return nil
+}
+
+// SetPaused pauses or resumes tpl.ID. Pausing removes it from the due-fire
+// index so ticks skip it; resuming recomputes its next fire time from now.
+func (m *Manager) SetPaused(ctx context.Context, id string, paused bool) error {
+	tpl, err := m.getTemplate(ctx, id)
+	if err != nil {
+		return fmt.Errorf("scheduler: load template %s: %w", id, err)
+	}
+	if tpl == nil {
+		return fmt.Errorf("scheduler: template %s not found", id)
+	}
+
+	if err := m.rdb.HSet(ctx, templateKey(id), "paused", strconv.FormatBool(paused)).Err(); err != nil {
+		return fmt.Errorf("scheduler: update template %s: %w", id, err)
+	}
+
+	if paused {
+		return m.rdb.ZRem(ctx, periodicSetKey, id).Err()
+	}
+
+	next, err := firstFireTime(tpl.Spec)
+	if err != nil {
+		return fmt.Errorf("scheduler: template %s: %w", id, err)
+	}
+	return m.scheduleNext(ctx, id, next)
+}
+
+// firstFireTime computes the next fire time for a freshly created or
+// resumed template, relative to now.
+func firstFireTime(spec string) (time.Time, error) {
+	sched, oneShotAt, err := parseSpec(spec)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if sched == nil {
+		return oneShotAt, nil
+	}
+	return sched.next(time.Now())
+}
+
+// scheduleNext ZADDs id into the due-fire index at fireAt.
+func (m *Manager) scheduleNext(ctx context.Context, id string, fireAt time.Time) error {
+	if err := m.rdb.ZAdd(ctx, periodicSetKey, &redis.Z{Score: float64(fireAt.Unix()), Member: id}).Err(); err != nil {
+		return fmt.Errorf("scheduler: schedule template %s: %w", id, err)
+	}
+	return nil
+}
+
+// saveTemplate writes every field of tpl into its hash.
+func (m *Manager) saveTemplate(ctx context.Context, tpl *Template) error {
+	fields := map[string]interface{}{
+		"id":                  tpl.ID,
+		"spec":                tpl.Spec,
+		"fen":                 tpl.FEN,
+		"elo":                 tpl.Elo,
+		"max_time_ms":         tpl.MaxTimeMs,
+		"paused":              strconv.FormatBool(tpl.Paused),
+		"missed_fires_policy": string(tpl.MissedFires),
+		"created_at":          tpl.CreatedAt,
+	}
+	if tpl.LastFiredAt != "" {
+		fields["last_fired_at"] = tpl.LastFiredAt
+	}
+
+	if err := m.rdb.HSet(ctx, templateKey(tpl.ID), fields).Err(); err != nil {
+		return fmt.Errorf("scheduler: save template %s: %w", tpl.ID, err)
+	}
+	return nil
+}
+
+// getTemplate loads tpl.ID's hash, returning nil if it does not exist.
+func (m *Manager) getTemplate(ctx context.Context, id string) (*Template, error) {
+	fields, err := m.rdb.HGetAll(ctx, templateKey(id)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	elo, _ := strconv.Atoi(fields["elo"])
+	maxTimeMs, _ := strconv.Atoi(fields["max_time_ms"])
+	paused, _ := strconv.ParseBool(fields["paused"])
+
+	return &Template{
+		ID:          fields["id"],
+		Spec:        fields["spec"],
+		FEN:         fields["fen"],
+		Elo:         elo,
+		MaxTimeMs:   maxTimeMs,
+		Paused:      paused,
+		MissedFires: MissedFiresPolicy(fields["missed_fires_policy"]),
+		CreatedAt:   fields["created_at"],
+		LastFiredAt: fields["last_fired_at"],
+	}, nil
+}
@@ -16,11 +16,11 @@ import (
     "context"
     "encoding/json"
     "fmt"
-    "io/ioutil"
     "net"
     "net/http"
     "os"
     "os/signal"
+    rtmetrics "runtime/metrics"
     "strconv"
     "strings"
     "sync"
@@ -31,37 +31,143 @@ import (
     "github.com/go-redis/redis/v8"
     "github.com/prometheus/client_golang/prometheus/promhttp"
     "github.com/sony/gobreaker"
-    
+
+    "stockfish-scale/pkg/cgroupstat"
     "stockfish-scale/pkg/circuitbreaker"
     "stockfish-scale/pkg/correlation"
+    "stockfish-scale/pkg/cputime"
+    "stockfish-scale/pkg/enginepool"
+    "stockfish-scale/pkg/jobstats"
     "stockfish-scale/pkg/k8s"
+    "stockfish-scale/pkg/keys"
     "stockfish-scale/pkg/logging"
     "stockfish-scale/pkg/metrics"
+    "stockfish-scale/pkg/metrics/verifier"
+    "stockfish-scale/pkg/movecache"
+    "stockfish-scale/pkg/redisconn"
     "stockfish-scale/pkg/retry"
 )
 
 var (
-    rdb           *redis.Client
+    rdb           redis.UniversalClient
     ctx           = context.Background()
     metricsCol    *metrics.MetricsCollector
+    metricsExporter metrics.Exporter
     logger        logging.Logger
+    auditWriter   *logging.StdoutAuditWriter
     stockfishCB   *gobreaker.CircuitBreaker
+    enginePool    *enginepool.Pool
+    resultPublishCB *circuitbreaker.AsyncCircuitBreaker
     activeJobsCount int32
     shutdownChan  chan struct{}
     doneChan      chan struct{}
+
+    // Metrics self-verification (nil unless VERIFIER_PROMETHEUS_URL is set)
+    driftVerifier *verifier.Verifier
+
+    // Job lifecycle tracking (crash recovery of in-flight jobs)
+    jobStats *jobstats.Manager
+    workerID string
     
     // Cost efficiency tracking (Requirement 5.2, 5.3)
     totalOperations int64
     lastCPUTime     time.Duration
+    lastCPUTimes    cputime.Times
     cpuTrackingMu   sync.Mutex
-    
-    // Idle time tracking (Requirement 5.5)
-    totalIdleTime     time.Duration
-    totalProcessTime  time.Duration
-    idleTrackingMu    sync.Mutex
-    workerStartTime   time.Time
+    cpuTimeSource   = cputime.New()
+
+    // Previous runtime/metrics /cpu/classes/... snapshot, for computing
+    // per-class deltas on each updateCPUMetrics tick
+    lastGCTotalSeconds  float64
+    lastGCPauseSeconds  float64
+    lastIdleSeconds     float64
+    lastScavengeSeconds float64
+
+    // Cgroup CPU throttling tracking (Requirement 5.2 container awareness)
+    cgroupTrackingMu   sync.Mutex
+    lastThrottledUsec  uint64
+    lastThrottleEvents uint64
+
+    // Idle time tracking (Requirement 5.5): a ring buffer of (timestamp,
+    // idleDelta, processDelta) samples covering the last idleWindowMax plus
+    // an EWMA, instead of one lifetime total that stays near 100% long after
+    // a quiet worker starts a sustained burst of jobs
+    idleSamples    []idleSample
+    idleTrackingMu sync.Mutex
+    idleEWMA       = 100.0
+    idleEWMAAlpha  = defaultIdleEWMAAlpha
+
+    // Pipelined result publishing (opt-in batching of publishResult's SET+PUBLISH)
+    resultBatchingEnabled bool
+    resultFlushInterval   time.Duration
+    resultFlushBatch      int
+    resultBatchChan       chan *bufferedResult
+    resultBatchStopChan   chan struct{}
+    resultBatchStoppedChan chan struct{}
+
+    // jobStreamArgs is the Streams slice passed to every XREADGROUP call:
+    // every shard's stream key followed by an equal number of ">" IDs
+    // (only-new-messages), built once since keys.AllJobsQueues() is static.
+    jobStreamArgs []string
+
+    // cpuClassSamples are the runtime/metrics keys updateCPUMetrics reads on
+    // each tick, mirroring the /cpu/classes/... tree.
+    cpuClassSamples = []rtmetrics.Sample{
+        {Name: "/cpu/classes/gc/total:cpu-seconds"},
+        {Name: "/cpu/classes/gc/pause:cpu-seconds"},
+        {Name: "/cpu/classes/idle:cpu-seconds"},
+        {Name: "/cpu/classes/scavenge/total:cpu-seconds"},
+        {Name: "/sched/goroutines:goroutines"},
+    }
+)
+
+const (
+    // jobHeartbeatInterval is how often handleJob refreshes a claimed job's
+    // heartbeat while it is waiting on Stockfish.
+    jobHeartbeatInterval = 3 * time.Second
+
+    // staleJobTimeout is how long a job can go without a heartbeat before a
+    // recovery scan considers its owning worker dead.
+    staleJobTimeout = 45 * time.Second
+
+    // idleWindowMax is the longest trailing window calculateIdlePercentage
+    // reports (Requirement 5.5); samples older than this are pruned.
+    idleWindowMax = 15 * time.Minute
+
+    // defaultIdleEWMAAlpha is the smoothing factor for idleEWMA when
+    // IDLE_EWMA_ALPHA isn't set. Lower values weight history more heavily.
+    defaultIdleEWMAAlpha = 0.1
+
+    // resultTTL bounds how long keys.ResultKey(jobID) survives in Redis. It
+    // only needs to outlive the window between a worker's PUBLISH and an api
+    // instance's fallback GET (the missed-notification race), so this is
+    // generous padding, not a retention policy.
+    resultTTL = 10 * time.Minute
+
+    // resultPublishMaxConcurrent bounds how many publishResultDirect retries
+    // resultPublishCB runs at once, so a Redis outage can't grow the
+    // worker's goroutine count without bound.
+    resultPublishMaxConcurrent = 32
+
+    // streamReapInterval is how often reapAbandonedStreamEntries scans every
+    // shard's pending entry list for messages a dead consumer never XACKed.
+    streamReapInterval = 15 * time.Second
+
+    // streamReclaimIdle is how long an XREADGROUP-delivered entry can go
+    // unacknowledged before the reaper assumes its consumer died and
+    // XCLAIMs it for redelivery to a live one.
+    streamReclaimIdle = 30 * time.Second
 )
 
+// idleSample is one (timestamp, idleDelta, processDelta) observation in the
+// idle-tracking ring buffer. Exactly one of idleDelta/processDelta is
+// non-zero per sample.
+type idleSample struct {
+    at           time.Time
+    idleDelta    time.Duration
+    processDelta time.Duration
+}
+
 type Job struct {
     JobID        string `json:"job_id"`
     CorrelationID string `json:"correlation_id,omitempty"` // Requirement 8.3: Extract correlation ID
@@ -80,39 +186,146 @@ type JobResult struct {
     Error         string            `json:"error,omitempty"`
     Timings       map[string]int64  `json:"timings,omitempty"` // Requirement 1.2, 1.3, 1.4: Add timing data
     CompletedAt   string            `json:"completed_at,omitempty"`
+    CancelledAt   string            `json:"cancelled_at,omitempty"`
+}
+
+// ProgressEvent is one incremental update published on
+// keys.ProgressChannel(job.JobID) while a job is in flight: an "info" event
+// per engine info line, then a final "bestmove" or "error". Repeated in
+// api/main.go for clarity, same note as Job/JobResult above: don't change
+// the shape without updating both.
+type ProgressEvent struct {
+    JobID         string `json:"job_id"`
+    CorrelationID string `json:"correlation_id,omitempty"`
+    Type          string `json:"type"` // "info", "bestmove", or "error"
+    Line          string `json:"line,omitempty"`
+    BestMove      string `json:"bestmove,omitempty"`
+    Ponder        string `json:"ponder,omitempty"`
+    Error         string `json:"error,omitempty"`
+}
+
+// publishProgress PUBLISHes one incremental update to
+// keys.ProgressChannel(event.JobID) for the api's SSE handler to forward.
+// Best-effort: there usually is no subscriber at all (a plain /move caller
+// never opens one), and a publish failure here must never fail the job.
+func publishProgress(ctx context.Context, event ProgressEvent) {
+    data, err := json.Marshal(event)
+    if err != nil {
+        return
+    }
+    rdb.Publish(ctx, keys.ProgressChannel(event.JobID), data)
 }
 
 func main() {
-    // Initialize structured logger (Requirement 8.6, 8.7)
-    logger = logging.NewLogger("worker")
+    // Initialize structured logger (Requirement 8.6, 8.7), additionally
+    // fanning every entry out to a tamper-evident audit trail separate from
+    // the operational slog.Handler - stdout JSON today, a stand-in for the
+    // Kafka/S3/webhook writer a real deployment would register instead.
+    auditWriter = &logging.StdoutAuditWriter{}
+    auditMux := logging.NewChanneledLogMux(logging.WriterConfig{Writer: auditWriter})
+    logger = logging.NewLoggerWithMux("worker", auditMux)
     logger.Info("Worker service starting")
     
-    // Initialize metrics collector (Requirement 1.2, 1.3, 1.4, 1.7, 1.8)
-    metricsCol = metrics.NewMetricsCollector("worker")
-    
+    // Initialize metrics collector (Requirement 1.2, 1.3, 1.4, 1.7, 1.8).
+    // METRICS_EXPORTER_KIND defaults to "prometheus" (today's /metrics
+    // scrape path, metricsExporter a no-op); set it to "otlp" or "both" to
+    // additionally push to METRICS_OTLP_ENDPOINT.
+    var err error
+    metricsCol, metricsExporter, err = metrics.NewMetricsCollectorWithExporter("worker", metrics.ExporterConfigFromEnv())
+    if err != nil {
+        logger.Error("Invalid metrics exporter configuration", err)
+        os.Exit(1)
+    }
+    if err := metricsExporter.Start(); err != nil {
+        logger.Error("Failed to start metrics exporter", err)
+        os.Exit(1)
+    }
+    defer metricsExporter.Stop(context.Background())
+
     // Get configuration from environment
     redisAddr := getenv("REDIS_ADDR", "redis:6379")
     engineAddr := getenv("ENGINE_ADDR", "stockfish:4000")
     metricsPort := getenv("METRICS_PORT", "9090")
     
-    // Initialize Redis client
-    rdb = redis.NewClient(&redis.Options{Addr: redisAddr})
-    _, err := rdb.Ping(ctx).Result()
+    // Initialize Redis client. pkg/redisconn reads REDIS_MODE so single,
+    // Sentinel, and Cluster deployments all return a redis.UniversalClient
+    // without changing the rdb calls below.
+    redisConf := redisconn.ConfigFromEnv()
+    redisConf.OnFailover = func(masterName string) {
+        logger.WithField("sentinel_master", masterName).Info("Redis Sentinel failover detected")
+        metricsCol.IncrementRedisFailovers("worker", masterName)
+    }
+    rdb, err = redisconn.New(redisConf)
+    if err != nil {
+        logger.Error("Invalid Redis configuration", err)
+        os.Exit(1)
+    }
+    _, err = rdb.Ping(ctx).Result()
     if err != nil {
         logger.Error("Redis connection failed", err)
     } else {
-        logger.WithField("redis_addr", redisAddr).Info("Connected to Redis")
+        logger.WithField("redis_mode", string(redisConf.Mode)).Info("Connected to Redis")
     }
     
     // Initialize circuit breaker for Stockfish connections (Requirement 3.1-3.5)
     stockfishCB = circuitbreaker.NewStockfishCircuitBreaker(metricsCol)
-    
+
+    // Initialize the warm Stockfish connection pool. The circuit breaker now
+    // guards pool acquisition instead of a per-job net.Dial, since a warm
+    // connection that already completed its uci/uciok handshake is reused
+    // across jobs instead of being dialed fresh each time.
+    enginePool = enginepool.New(engineAddr, enginepool.DefaultConfig(), stockfishCB, metricsCol)
+
+    // Initialize the async circuit breaker guarding Redis result publishing
+    // (chunk5-6): a result that can't be published is dropped rather than
+    // blocking the worker from picking up its next job.
+    resultPublishCB = circuitbreaker.NewAsyncCircuitBreaker(
+        "redis-result-publish",
+        circuitbreaker.RedisResultAsyncCircuitBreakerConfig(),
+        resultPublishMaxConcurrent,
+        metricsCol,
+        "redis",
+        "result_publish",
+    )
+
+    // Initialize job lifecycle tracking, identifying this process so crash
+    // recovery can tell its own abandoned jobs apart from other workers'
+    workerID = fmt.Sprintf("worker-%s-%d", getenv("HOSTNAME", "unknown"), os.Getpid())
+    jobStats = jobstats.NewManager(rdb, workerID)
+    recoverStaleJobs()
+
+    // Ensure every shard's job stream has the shared consumer group this
+    // worker's XREADGROUP joins, so a worker starting against a fresh
+    // deployment (no prior XADD traffic) doesn't NOGROUP-error on its first read.
+    ensureStreamGroups()
+    jobStreamArgs = buildJobStreamArgs()
+
     // Initialize shutdown channels (Requirement 6.7)
     shutdownChan = make(chan struct{})
     doneChan = make(chan struct{})
-    
+
+    // Initialize optional pipelined result publishing (opt-in, off by default
+    // so low-latency deployments keep the existing per-result SET+PUBLISH path)
+    initResultBatcher()
+    if resultBatchingEnabled {
+        go runResultBatcher()
+    }
+
     // Start HTTP server for metrics and health endpoints (Requirement 3.7)
     go startHTTPServer(metricsPort, engineAddr)
+
+    // Periodically publish job-status gauges from the jobstats active index
+    go trackJobStats()
+
+    // Periodically report the audit log writer's backlog
+    go trackAuditLogBufferSize()
+
+    // Subscribe to per-job cancellation signals before we start popping jobs
+    go subscribeJobControl()
+
+    // Reclaim stream entries abandoned by workers that died mid-job
+    // (Requirement: at-least-once delivery via XPENDING/XCLAIM)
+    go reapAbandonedStreamEntries(engineAddr)
     
     // Setup graceful shutdown (Requirement 6.7)
     sigChan := make(chan os.Signal, 1)
@@ -130,9 +343,16 @@ func main() {
         "metrics_port": metricsPort,
     }).Info("Worker started")
     
-    // Initialize worker start time for idle percentage calculation (Requirement 5.5)
-    workerStartTime = time.Now()
-    
+    // Configure the EWMA smoothing factor for idle percentage calculation
+    // (Requirement 5.5)
+    if v := os.Getenv("IDLE_EWMA_ALPHA"); v != "" {
+        if alpha, err := strconv.ParseFloat(v, 64); err == nil && alpha > 0 && alpha <= 1 {
+            idleEWMAAlpha = alpha
+        } else {
+            logger.WithField("value", v).Warn("Invalid IDLE_EWMA_ALPHA, using default")
+        }
+    }
+
     // Start CPU tracking goroutine (Requirement 5.2, 5.3)
     go trackCPUAndEfficiency()
     
@@ -147,13 +367,26 @@ func main() {
         replicaTracker.Start()
         defer replicaTracker.Stop()
     }
-    
+
+    // Start the metrics self-verification loop (Requirement: catch broken
+    // exporter wiring or lost scrape targets without external tooling)
+    driftVerifier, err = verifier.NewVerifier(verifier.ConfigFromEnv(), metricsCol, logger)
+    if err != nil {
+        logger.WithField("error", err.Error()).Warn("Failed to create metrics verifier")
+    } else if driftVerifier != nil {
+        driftVerifier.Start()
+        defer driftVerifier.Stop()
+    }
+
     // Main job processing loop
     processJobs(engineAddr)
     
     // Wait for graceful shutdown to complete
     <-doneChan
-    
+
+    // Stop the engine pool's health-check loop and close its idle connections
+    enginePool.Close()
+
     // Close Redis connection cleanly (Requirement 6.7)
     if err := rdb.Close(); err != nil {
         logger.Error("Error closing Redis connection", err)
@@ -176,7 +409,20 @@ func startHTTPServer(port, engineAddr string) {
     mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
         healthCheck(w, r, engineAddr)
     })
-    
+
+    // Job lifecycle lookup endpoint
+    mux.HandleFunc("/jobs/", jobStatusHandler)
+
+    // Metrics self-verification report (nil until driftVerifier's first tick,
+    // or absent entirely if VERIFIER_PROMETHEUS_URL isn't set)
+    mux.HandleFunc("/debug/verify", func(w http.ResponseWriter, r *http.Request) {
+        if driftVerifier == nil {
+            http.Error(w, "metrics verifier disabled: VERIFIER_PROMETHEUS_URL not set", http.StatusNotFound)
+            return
+        }
+        driftVerifier.DebugHandler()(w, r)
+    })
+
     addr := ":" + port
     logger.WithField("port", port).Info("Starting HTTP server for metrics and health")
     
@@ -185,6 +431,127 @@ func startHTTPServer(port, engineAddr string) {
     }
 }
 
+// ensureStreamGroups creates keys.JobsGroup on every shard's job stream if
+// it doesn't already exist, starting from "0" (the beginning of the stream)
+// so this worker's first XREADGROUP doesn't NOGROUP-error against a shard no
+// consumer has ever joined before. MKSTREAM also covers a shard whose stream
+// key doesn't exist yet because no job has ever been XADDed to it.
+func ensureStreamGroups() {
+    for _, queueKey := range keys.AllJobsQueues() {
+        err := rdb.XGroupCreateMkStream(ctx, queueKey, keys.JobsGroup, "0").Err()
+        if err != nil && !strings.Contains(err.Error(), "BUSYGROUP") {
+            logger.WithField("queue_key", queueKey).Error("Failed to create stream consumer group", err)
+        }
+    }
+}
+
+// buildJobStreamArgs returns the Streams slice every XREADGROUP call reuses:
+// every shard's stream key, followed by an equal number of ">" IDs meaning
+// "only messages never delivered to any consumer in this group".
+func buildJobStreamArgs() []string {
+    queues := keys.AllJobsQueues()
+    args := make([]string, 0, len(queues)*2)
+    for _, queueKey := range queues {
+        args = append(args, queueKey)
+    }
+    for range queues {
+        args = append(args, ">")
+    }
+    return args
+}
+
+// ackStreamEntry XACKs a delivered stream entry once its job has finished
+// processing (successfully or not), removing it from the consumer group's
+// pending entries list so reapAbandonedStreamEntries never reclaims it.
+func ackStreamEntry(stream, id string) {
+    if err := rdb.XAck(ctx, stream, keys.JobsGroup, id).Err(); err != nil {
+        logger.WithFields(map[string]interface{}{
+            "stream": stream,
+            "id":     id,
+        }).Error("Failed to XACK stream entry", err)
+    }
+}
+
+// reapAbandonedStreamEntries periodically scans every shard's pending entry
+// list for messages idle longer than streamReclaimIdle - delivered to a
+// consumer via XREADGROUP that then died before XACKing - and XCLAIMs them
+// onto this worker so at-least-once delivery holds even though the original
+// consumer is gone.
+func reapAbandonedStreamEntries(engineAddr string) {
+    ticker := time.NewTicker(streamReapInterval)
+    defer ticker.Stop()
+
+    for {
+        select {
+        case <-shutdownChan:
+            return
+        case <-ticker.C:
+            for _, queueKey := range keys.AllJobsQueues() {
+                reclaimShardEntries(engineAddr, queueKey)
+            }
+        }
+    }
+}
+
+// reclaimShardEntries XCLAIMs queueKey's pending entries that have gone idle
+// past streamReclaimIdle and hands each one to handleJob exactly as if it had
+// just been delivered by XREADGROUP.
+func reclaimShardEntries(engineAddr, queueKey string) {
+    pending, err := rdb.XPendingExt(ctx, &redis.XPendingExtArgs{
+        Stream: queueKey,
+        Group:  keys.JobsGroup,
+        Start:  "-",
+        End:    "+",
+        Count:  100,
+        Idle:   streamReclaimIdle,
+    }).Result()
+    if err != nil || len(pending) == 0 {
+        return
+    }
+
+    ids := make([]string, len(pending))
+    for i, p := range pending {
+        ids[i] = p.ID
+    }
+
+    claimed, err := rdb.XClaim(ctx, &redis.XClaimArgs{
+        Stream:   queueKey,
+        Group:    keys.JobsGroup,
+        Consumer: workerID,
+        MinIdle:  streamReclaimIdle,
+        Messages: ids,
+    }).Result()
+    if err != nil {
+        logger.WithField("queue_key", queueKey).Error("Failed to XCLAIM abandoned stream entries", err)
+        return
+    }
+
+    metricsCol.IncrementStreamReclaimed(float64(len(claimed)))
+
+    for _, msg := range claimed {
+        raw, ok := msg.Values["job"].(string)
+        if !ok {
+            logger.WithField("id", msg.ID).Error("Invalid reclaimed job payload", nil)
+            ackStreamEntry(queueKey, msg.ID)
+            continue
+        }
+
+        var job Job
+        if err := json.Unmarshal([]byte(raw), &job); err != nil {
+            logger.WithField("id", msg.ID).Error("Invalid reclaimed job payload", err)
+            ackStreamEntry(queueKey, msg.ID)
+            continue
+        }
+
+        logger.WithFields(map[string]interface{}{
+            "job_id": job.JobID,
+            "stream": queueKey,
+        }).Info("Reclaimed job abandoned by a dead consumer")
+
+        go handleJob(engineAddr, job, queueKey, msg.ID)
+    }
+}
+
 // processJobs is the main job processing loop
 func processJobs(engineAddr string) {
     idleStart := time.Now()
@@ -196,9 +563,8 @@ func processJobs(engineAddr string) {
             
             // Track final idle period before shutdown (Requirement 5.5)
             if time.Since(idleStart) > 0 {
-                idleDuration := time.Since(idleStart)
-                metricsCol.IncrementIdleTime(idleDuration)
-                recordIdleTime(idleDuration)
+                metricsCol.IncrementIdleTime(time.Since(idleStart))
+                recordIdleTime(idleStart)
             }
             
             // Wait for current job to complete with 30s timeout (Requirement 6.7)
@@ -212,13 +578,15 @@ func processJobs(engineAddr string) {
             for {
                 if atomic.LoadInt32(&activeJobsCount) == 0 {
                     logger.Info("All jobs completed, shutting down")
+                    stopResultBatcher()
                     close(doneChan)
                     return
                 }
-                
+
                 select {
                 case <-shutdownCtx.Done():
                     logger.Warn("Shutdown timeout reached, forcing exit")
+                    stopResultBatcher()
                     close(doneChan)
                     return
                 case <-ticker.C:
@@ -227,95 +595,86 @@ func processJobs(engineAddr string) {
             }
             
         default:
-            // Try to get a job from Redis with timeout
-            res, err := rdb.BLPop(ctx, 5*time.Second, "stockfish:jobs").Result()
+            // Try to get a job from Redis with timeout. XREADGROUP across
+            // every shard's stream key so no shard goes unserved
+            // (Requirement: Redis Cluster support via hash-tag-scoped keys),
+            // joined under keys.JobsGroup so a crashed worker's undelivered
+            // entries stay pending for reapAbandonedStreamEntries instead of
+            // vanishing the way a popped list entry would.
+            res, err := rdb.XReadGroup(ctx, &redis.XReadGroupArgs{
+                Group:    keys.JobsGroup,
+                Consumer: workerID,
+                Streams:  jobStreamArgs,
+                Count:    1,
+                Block:    5 * time.Second,
+            }).Result()
             if err != nil {
                 if err == redis.Nil {
                     // No jobs available, continue idle tracking (Requirement 5.5)
                     // We'll track idle time when we get a job or periodically
                     continue
                 }
-                logger.Error("Redis BLPOP error", err)
+                logger.Error("Redis XREADGROUP error", err)
                 time.Sleep(1 * time.Second)
                 continue
             }
-            
+
             // Job received - record idle time (Requirement 5.5)
-            idleDuration := time.Since(idleStart)
-            if idleDuration > 0 {
-                metricsCol.IncrementIdleTime(idleDuration)
-                recordIdleTime(idleDuration)
+            if time.Since(idleStart) > 0 {
+                metricsCol.IncrementIdleTime(time.Since(idleStart))
+                recordIdleTime(idleStart)
             }
-            
-            if len(res) < 2 {
+
+            if len(res) == 0 || len(res[0].Messages) == 0 {
                 // Reset idle timer for next iteration
                 idleStart = time.Now()
                 continue
             }
-            
+
+            stream := res[0].Stream
+            msg := res[0].Messages[0]
+
+            raw, ok := msg.Values["job"].(string)
+            if !ok {
+                logger.Error("Invalid job payload", nil)
+                ackStreamEntry(stream, msg.ID)
+                // Reset idle timer for next iteration
+                idleStart = time.Now()
+                continue
+            }
+
             var job Job
-            if err := json.Unmarshal([]byte(res[1]), &job); err != nil {
+            if err := json.Unmarshal([]byte(raw), &job); err != nil {
                 logger.Error("Invalid job payload", err)
+                ackStreamEntry(stream, msg.ID)
                 // Reset idle timer for next iteration
                 idleStart = time.Now()
                 continue
             }
-            
+
+            // Record the claim before handing off so a crash between dequeue
+            // and completion is still visible to recoverStaleJobs on restart
+            if err := jobStats.Claim(ctx, job.JobID, raw); err != nil {
+                logger.WithField("job_id", job.JobID).Error("Failed to record job claim", err)
+            }
+
             // Process job in goroutine
             // Note: We track processing time in handleJob
-            go handleJob(engineAddr, job)
-            
+            go handleJob(engineAddr, job, stream, msg.ID)
+
             // Reset idle timer for next iteration
             idleStart = time.Now()
         }
     }
 }
 
-// connectToStockfish connects to Stockfish with circuit breaker and retry logic
+// acquireEngineConn acquires a warm, handshaked connection from enginePool,
+// translating circuit-breaker-open errors into the same operator-facing
+// messages connectToStockfish used to return for a failed dial.
 // Requirements 3.1-3.5: Circuit breaker protection with 5 failures in 60s threshold
-// Requirements 4.1, 4.2, 4.5, 4.7: Retry with exponential backoff
-func connectToStockfish(ctx context.Context, engineAddr string, jobLogger logging.Logger) (net.Conn, error) {
-	var conn net.Conn
-	var lastErr error
-	var attemptCount int
-	
-	// Wrap Stockfish TCP dial operations with circuit breaker (Requirement 3.3)
-	_, err := stockfishCB.Execute(func() (interface{}, error) {
-		// Use retry logic with exponential backoff (Requirement 4.1, 4.2, 4.5, 4.7)
-		retryCfg := retry.StockfishRetryConfig()
-		
-		// Add retry callback to log each retry attempt with backoff duration (Requirement 4.7)
-		retryCfg.OnRetry = func(attempt int, delay time.Duration, err error) {
-			jobLogger.WithFields(map[string]interface{}{
-				"attempt":       attempt,
-				"max_attempts":  retryCfg.MaxAttempts,
-				"backoff_ms":    delay.Milliseconds(),
-				"engine_addr":   engineAddr,
-				"error":         err.Error(),
-			}).Warn("Retrying Stockfish connection after backoff")
-		}
-		
-		retryErr := retry.WithRetry(ctx, retryCfg, func() error {
-			attemptCount++
-			c, dialErr := net.DialTimeout("tcp", engineAddr, 5*time.Second)
-			if dialErr != nil {
-				lastErr = dialErr
-				jobLogger.WithFields(map[string]interface{}{
-					"engine_addr": engineAddr,
-					"attempt":     attemptCount,
-					"error":       dialErr.Error(),
-				}).Warn("Stockfish connection attempt failed")
-				return dialErr
-			}
-			conn = c
-			return nil
-		})
-		
-		return conn, retryErr
-	})
-	
+func acquireEngineConn(ctx context.Context, engineAddr string, jobLogger logging.Logger) (*enginepool.Conn, error) {
+	conn, err := enginePool.Acquire(ctx)
 	if err != nil {
-		// Fail jobs immediately when circuit is open (Requirement 3.2, 3.3)
 		if err == gobreaker.ErrOpenState {
 			jobLogger.WithFields(map[string]interface{}{
 				"circuit_state": "open",
@@ -323,8 +682,7 @@ func connectToStockfish(ctx context.Context, engineAddr string, jobLogger loggin
 			}).Error("Circuit breaker open, failing job immediately", err)
 			return nil, fmt.Errorf("stockfish service temporarily unavailable (circuit breaker open, will retry in 30s)")
 		}
-		
-		// Circuit breaker is closed or half-open, but connection failed after retries
+
 		if err == gobreaker.ErrTooManyRequests {
 			jobLogger.WithFields(map[string]interface{}{
 				"circuit_state": "half-open",
@@ -332,18 +690,17 @@ func connectToStockfish(ctx context.Context, engineAddr string, jobLogger loggin
 			}).Error("Circuit breaker half-open, test connection failed", err)
 			return nil, fmt.Errorf("stockfish service test connection failed (circuit breaker half-open)")
 		}
-		
+
 		jobLogger.WithFields(map[string]interface{}{
-			"engine_addr":   engineAddr,
-			"total_attempts": attemptCount,
-		}).Error("Failed to connect to Stockfish after retries", lastErr)
-		return nil, fmt.Errorf("failed to connect to stockfish: %w", lastErr)
+			"engine_addr": engineAddr,
+		}).Error("Failed to acquire pooled Stockfish connection", err)
+		return nil, fmt.Errorf("failed to connect to stockfish: %w", err)
 	}
-	
+
 	return conn, nil
 }
 
-func handleJob(engineAddr string, job Job) {
+func handleJob(engineAddr string, job Job, stream, msgID string) {
     // Track active jobs (Requirement 6.5)
     atomic.AddInt32(&activeJobsCount, 1)
     defer atomic.AddInt32(&activeJobsCount, -1)
@@ -361,9 +718,17 @@ func handleJob(engineAddr string, job Job) {
         correlationID = gen.Generate()
     }
     
-    // Store correlation ID in goroutine context (Requirement 8.3)
+    // Store correlation ID in goroutine context (Requirement 8.3). jobCtx is
+    // used for Redis operations (jobstats, result publishing) and must stay
+    // usable even after a cancellation, so the cancellable context used to
+    // interrupt Stockfish is kept separate as computeCtx.
     jobCtx := correlation.WithID(context.Background(), correlationID)
-    
+    computeCtx, cancelJob := context.WithCancel(jobCtx)
+    defer cancelJob()
+
+    controlEntry := registerJobControl(job.JobID, cancelJob)
+    defer unregisterJobControl(job.JobID)
+
     // Create logger with correlation ID for all log entries (Requirement 8.6)
     jobLogger := logger.WithCorrelationID(correlationID).WithFields(map[string]interface{}{
         "job_id": job.JobID,
@@ -372,7 +737,18 @@ func handleJob(engineAddr string, job Job) {
     })
     
     jobLogger.Info("Processing job")
-    
+
+    // Transition pending -> running now that work has actually begun
+    if err := jobStats.MarkRunning(jobCtx, job.JobID); err != nil {
+        jobLogger.Error("Failed to mark job running", err)
+    }
+
+    // Refresh the heartbeat while this job is blocked on Stockfish so a
+    // recovery scan on a crashed worker doesn't mistake it for abandoned
+    heartbeatStop := make(chan struct{})
+    go jobStats.StartHeartbeat(jobCtx, job.JobID, jobHeartbeatInterval, heartbeatStop)
+    defer close(heartbeatStop)
+
     // Initialize result
     result := JobResult{
         JobID:         job.JobID,
@@ -392,49 +768,106 @@ func handleJob(engineAddr string, job Job) {
         }
     }
     
-    // Connect to Stockfish with circuit breaker and retry (Requirements 3.3, 4.1-4.2, 4.5, 4.7)
+    // Acquire a warm, pre-handshaked connection from the engine pool instead
+    // of dialing and re-running "uci"/"uciok" for every job (Requirement 3.3)
     connectionStart := time.Now()
-    conn, err := connectToStockfish(jobCtx, engineAddr, jobLogger)
+    pooled, err := acquireEngineConn(computeCtx, engineAddr, jobLogger)
     if err != nil {
         result.Error = fmt.Sprintf("engine connect error: %v", err)
         result.CompletedAt = time.Now().Format(time.RFC3339Nano)
-        publishResult(jobCtx, result, jobLogger)
-        
+        published := publishResult(jobCtx, result, jobLogger)
+        publishProgress(jobCtx, ProgressEvent{JobID: job.JobID, CorrelationID: correlationID, Type: "error", Error: result.Error})
+        if published {
+            ackStreamEntry(stream, msgID)
+        } else {
+            jobLogger.Warn("Leaving stream entry pending after dropped result publish, for reapAbandonedStreamEntries to reclaim")
+        }
+        if err := jobStats.Complete(jobCtx, job.JobID, jobstats.StatusFailed, result.Error); err != nil {
+            jobLogger.Error("Failed to record job completion", err)
+        }
+
         // Record metrics
         metricsCol.RecordTotalProcessingTime(time.Since(processingStart))
         return
     }
-    defer conn.Close()
-    
+
+    // Returned to the pool on success (or a clean cancellation); discarded if
+    // the protocol exchange failed and the connection's state is suspect
+    released := false
+    defer func() {
+        if !released {
+            pooled.Discard()
+        }
+    }()
+
+    // Attach the live connection so a cancel arriving from here on can write
+    // UCI "stop" directly to Stockfish; replays any cancel buffered earlier
+    controlEntry.attachConn(pooled)
+
     connectionDuration := time.Since(connectionStart)
     result.Timings["engine_connect_ms"] = connectionDuration.Milliseconds()
     metricsCol.RecordEngineConnectionTime(connectionDuration)
-    
-    jobLogger.WithField("connection_ms", connectionDuration.Milliseconds()).Info("Connected to engine")
-    
+
+    jobLogger.WithField("connection_ms", connectionDuration.Milliseconds()).Info("Acquired pooled engine connection")
+
     // Execute chess computation (Requirement 1.3)
     computeStart := time.Now()
-    bestMove, ponder, info, err := executeChessComputation(conn, job, jobLogger)
+    bestMove, ponder, info, err := executeChessComputation(computeCtx, pooled, job, jobLogger)
     computeDuration := time.Since(computeStart)
-    
+
     result.Timings["engine_compute_ms"] = computeDuration.Milliseconds()
     metricsCol.RecordEngineComputeTime(computeDuration)
-    
-    if err != nil {
+
+    if computeCtx.Err() == context.Canceled {
+        result.Error = "cancelled"
+        result.CancelledAt = time.Now().Format(time.RFC3339Nano)
+        result.BestMove = bestMove
+        jobLogger.Info("Job cancelled mid-flight")
+        if bestMove != "" {
+            pooled.Release()
+            released = true
+        }
+    } else if err != nil {
         result.Error = fmt.Sprintf("engine computation error: %v", err)
     } else {
         result.BestMove = bestMove
         result.Ponder = ponder
         result.Info = info
+        pooled.Release()
+        released = true
+
+        // Populate the move cache's Redis tier so a future identical
+        // (FEN, Elo, MoveTimeMs) request can skip the queue entirely.
+        cacheMoveResult(jobCtx, job, bestMove, ponder, info, jobLogger)
     }
-    
+
     result.CompletedAt = time.Now().Format(time.RFC3339Nano)
-    
+
+    // Publish the final SSE progress event before the result itself, so a
+    // streamHandler connection sees "bestmove"/"error" at roughly the same
+    // time a /move caller's waitForResult would unblock.
+    if result.Error != "" {
+        publishProgress(jobCtx, ProgressEvent{JobID: job.JobID, CorrelationID: correlationID, Type: "error", Error: result.Error})
+    } else {
+        publishProgress(jobCtx, ProgressEvent{JobID: job.JobID, CorrelationID: correlationID, Type: "bestmove", BestMove: result.BestMove, Ponder: result.Ponder})
+    }
+
     // Publish result with retry (Requirement 4.4, 4.6)
     publishStart := time.Now()
-    publishResult(jobCtx, result, jobLogger)
+    if publishResult(jobCtx, result, jobLogger) {
+        // XACK only after the result is durably published, so a crash between
+        // dequeue and publish leaves the entry pending for reapAbandonedStreamEntries
+        ackStreamEntry(stream, msgID)
+    } else {
+        jobLogger.WithFields(map[string]interface{}{
+            "job_id":   job.JobID,
+            "stream":   stream,
+            "msg_id":   msgID,
+            "operation": "redis_result_publish",
+        }).Warn("Leaving stream entry pending after dropped result publish, for reapAbandonedStreamEntries to reclaim")
+    }
     publishDuration := time.Since(publishStart)
-    
+
     result.Timings["result_publish_ms"] = publishDuration.Milliseconds()
     metricsCol.RecordResultPublishTime(publishDuration)
     
@@ -444,7 +877,7 @@ func handleJob(engineAddr string, job Job) {
     metricsCol.RecordTotalProcessingTime(totalDuration)
     
     // Track processing time for idle percentage calculation (Requirement 5.5)
-    recordProcessingTime(totalDuration)
+    recordProcessingTime(processingStart)
     
     // Log structured entry with all timings (Requirement 8.8)
     jobLogger.WithFields(map[string]interface{}{
@@ -461,21 +894,54 @@ func handleJob(engineAddr string, job Job) {
     if result.Error == "" {
         atomic.AddInt64(&totalOperations, 1)
     }
+
+    // Final lifecycle transition to a terminal status
+    finalStatus := jobstats.StatusSuccess
+    switch {
+    case result.CancelledAt != "":
+        finalStatus = jobstats.StatusCancelled
+    case result.Error != "":
+        finalStatus = jobstats.StatusFailed
+    }
+    if err := jobStats.Complete(jobCtx, job.JobID, finalStatus, result.Error); err != nil {
+        jobLogger.Error("Failed to record job completion", err)
+    }
 }
-// executeChessComputation executes the chess computation on the Stockfish engine
-func executeChessComputation(conn net.Conn, job Job, jobLogger logging.Logger) (string, string, string, error) {
-    reader := bufio.NewReader(conn)
-    
+
+// cacheMoveResult SETEXes a successfully completed job's result into the
+// move cache's Redis tier, keyed by its canonicalized
+// (FEN, Elo, MoveTimeMs). The api process's local tier is populated
+// separately when it reads that same result back.
+func cacheMoveResult(ctx context.Context, job Job, bestMove, ponder, info string, jobLogger logging.Logger) {
+    cacheKey := movecache.Key(job.FEN, job.Elo, job.MaxTime)
+    entry := movecache.Entry{BestMove: bestMove, Ponder: ponder, Info: info}
+    if err := movecache.SetRedis(ctx, rdb, cacheKey, entry, movecache.DefaultConfig().RedisTTL); err != nil {
+        jobLogger.Error("Failed to populate move cache", err)
+    }
+}
+
+// executeChessComputation runs one job's worth of UCI commands on an already
+// handshaked pooled connection (ucinewgame/isready/position/go), skipping the
+// "uci"/"uciok" exchange enginepool already completed when it dialed.
+// It watches ctx so a cancellation signal (stockfish:ctl:<job_id>) can unblock
+// the wait for bestmove as soon as Stockfish responds to UCI "stop"
+func executeChessComputation(ctx context.Context, pooled *enginepool.Conn, job Job, jobLogger logging.Logger) (string, string, string, error) {
+    conn := pooled.Conn
+    reader := pooled.Reader
+
     write := func(cmd string) error {
         _, err := fmt.Fprintf(conn, "%s\n", cmd)
         return err
     }
-    
+
     readUntil := func(substr string, timeout time.Duration) (string, error) {
         deadline := time.Now().Add(timeout)
         var lines []string
-        
+
         for time.Now().Before(deadline) {
+            if ctx.Err() != nil {
+                return strings.Join(lines, "\n"), ctx.Err()
+            }
             conn.SetReadDeadline(time.Now().Add(500 * time.Millisecond))
             line, err := reader.ReadString('\n')
             if err != nil {
@@ -493,16 +959,9 @@ func executeChessComputation(conn net.Conn, job Job, jobLogger logging.Logger) (
         return strings.Join(lines, "\n"), fmt.Errorf("timeout waiting for %q", substr)
     }
     
-    // Initialize UCI
-    if err := write("uci"); err != nil {
-        return "", "", "", fmt.Errorf("uci command error: %v", err)
-    }
-    
-    if _, err := readUntil("uciok", 3*time.Second); err != nil {
-        return "", "", "", fmt.Errorf("uci init error: %v", err)
-    }
-    
-    // Set ELO if specified
+    // Set ELO if specified. This is sent before ucinewgame so it's a fresh
+    // per-job option on the reused connection rather than carried over from
+    // whatever the previous job on this connection asked for.
     if job.Elo > 0 {
         if err := write("setoption name UCI_LimitStrength value true"); err != nil {
             jobLogger.Warn("Failed to set limit strength")
@@ -510,22 +969,28 @@ func executeChessComputation(conn net.Conn, job Job, jobLogger logging.Logger) (
         if err := write(fmt.Sprintf("setoption name UCI_Elo value %d", job.Elo)); err != nil {
             jobLogger.Warn("Failed to set ELO")
         }
+    } else {
+        // Explicitly disable strength limiting, otherwise a reused pooled
+        // connection keeps playing at whatever Elo the previous job set.
+        if err := write("setoption name UCI_LimitStrength value false"); err != nil {
+            jobLogger.Warn("Failed to reset limit strength")
+        }
     }
-    
+
+    // Start new game
+    if err := write("ucinewgame"); err != nil {
+        return "", "", "", fmt.Errorf("ucinewgame command error: %v", err)
+    }
+
     // Check if engine is ready
     if err := write("isready"); err != nil {
         return "", "", "", fmt.Errorf("isready command error: %v", err)
     }
-    
+
     if _, err := readUntil("readyok", 2*time.Second); err != nil {
         return "", "", "", fmt.Errorf("ready check error: %v", err)
     }
-    
-    // Start new game
-    if err := write("ucinewgame"); err != nil {
-        return "", "", "", fmt.Errorf("ucinewgame command error: %v", err)
-    }
-    
+
     // Set position
     if strings.TrimSpace(job.FEN) == "" {
         write("position startpos")
@@ -549,6 +1014,28 @@ func executeChessComputation(conn net.Conn, job Job, jobLogger logging.Logger) (
     var bestMove, ponder string
     
     for time.Now().Before(deadline) {
+        if ctx.Err() != nil {
+            // Cancelled: UCI "stop" was already written to conn by the
+            // control handler, so give Stockfish a brief window to emit its
+            // current-best bestmove rather than tearing the connection down
+            cancelDeadline := time.Now().Add(2 * time.Second)
+            for time.Now().Before(cancelDeadline) {
+                conn.SetReadDeadline(time.Now().Add(500 * time.Millisecond))
+                line, err := reader.ReadString('\n')
+                if err != nil {
+                    continue
+                }
+                line = strings.TrimSpace(line)
+                if strings.HasPrefix(line, "bestmove ") {
+                    fields := strings.Fields(line)
+                    if len(fields) >= 2 {
+                        bestMove = fields[1]
+                    }
+                    break
+                }
+            }
+            return bestMove, ponder, strings.Join(infoLines, "\n"), ctx.Err()
+        }
         conn.SetReadDeadline(time.Now().Add(500 * time.Millisecond))
         line, err := reader.ReadString('\n')
         if err != nil {
@@ -565,6 +1052,12 @@ func executeChessComputation(conn net.Conn, job Job, jobLogger logging.Logger) (
         
         if strings.HasPrefix(line, "info ") {
             infoLines = append(infoLines, line)
+            publishProgress(ctx, ProgressEvent{
+                JobID:         job.JobID,
+                CorrelationID: job.CorrelationID,
+                Type:          "info",
+                Line:          line,
+            })
         } else if strings.HasPrefix(line, "bestmove ") {
             fields := strings.Fields(line)
             if len(fields) >= 2 {
@@ -585,16 +1078,72 @@ func executeChessComputation(conn net.Conn, job Job, jobLogger logging.Logger) (
     return "", "", "", fmt.Errorf("timeout waiting for bestmove")
 }
 
-func publishResult(jobCtx context.Context, result JobResult, jobLogger logging.Logger) {
+// publishResult SETs result to its per-job key and PUBLISHes the job ID on
+// keys.ResultsNotifyChannel, either directly (the default, lowest-latency
+// path) or via the result batcher when RESULT_BATCHING_ENABLED opts into
+// pipelining both commands. Either way it blocks until the result has
+// actually been durably published - for the batched path that means
+// waiting on flushResultBatch's outcome for this entry, not just handing it
+// to resultBatchChan - so a true return is always safe grounds to XACK the
+// stream entry that produced it; a worker crash between handing a result to
+// the batcher and its flush must not look like a successful publish.
+func publishResult(jobCtx context.Context, result JobResult, jobLogger logging.Logger) bool {
     data, err := json.Marshal(result)
     if err != nil {
         jobLogger.Error("Error marshaling result", err)
-        return
+        return false
     }
-    
+
+    if resultBatchingEnabled {
+        done := make(chan bool, 1)
+        resultBatchChan <- &bufferedResult{jobCtx: jobCtx, result: result, data: data, jobLogger: jobLogger, done: done}
+        return <-done
+    }
+
+    return publishResultDirect(jobCtx, result, data, jobLogger)
+}
+
+// publishResultDirect hands the single-entry SET+PUBLISH-with-retry path off
+// to resultPublishCB, used both when batching is disabled and as the
+// per-entry fallback when a batched pipeline flush fails. It reports whether
+// the publish actually completed, so handleJob can skip the XACK on a drop
+// and leave the stream entry pending for reapAbandonedStreamEntries instead
+// of acking a result that was never written. The only case this returns
+// without waiting on publishResultWithRetry is when resultPublishCB drops the
+// submission outright (ErrCircuitOpen/ErrQueueFull) - that's the one case
+// where there is nothing to wait for, and is exactly the "Redis is down,
+// don't block the next job" scenario AsyncCircuitBreaker exists for. Once a
+// submission is accepted, publishResultDirect still blocks until it actually
+// completes, so the at-least-once XACK-after-publish guarantee this package
+// documents holds for the common (Redis healthy) case, not just when
+// batching is off.
+func publishResultDirect(jobCtx context.Context, result JobResult, data []byte, jobLogger logging.Logger) bool {
+    done := make(chan error, 1)
+    submitErr := resultPublishCB.SubmitAsync(func() error {
+        err := publishResultWithRetry(jobCtx, result, data, jobLogger)
+        done <- err
+        return err
+    })
+
+    if submitErr != nil {
+        jobLogger.WithFields(map[string]interface{}{
+            "job_id":    result.JobID,
+            "operation": "redis_result_publish",
+            "error":     submitErr.Error(),
+        }).Warn("Dropped Redis result publish, circuit breaker rejected submission")
+        return false
+    }
+
+    return <-done == nil
+}
+
+// publishResultWithRetry performs the actual SET+PUBLISH-with-retry Redis
+// round trip; it runs on resultPublishCB's worker pool, not the caller's
+// goroutine.
+func publishResultWithRetry(jobCtx context.Context, result JobResult, data []byte, jobLogger logging.Logger) error {
     // Use retry logic for Redis result publishing (Requirement 4.4, 4.6)
     retryCfg := retry.RedisResultRetryConfig()
-    
+
     // Add retry callback to log each retry attempt with backoff duration (Requirement 4.7)
     retryCfg.OnRetry = func(attempt int, delay time.Duration, err error) {
         jobLogger.WithFields(map[string]interface{}{
@@ -605,11 +1154,15 @@ func publishResult(jobCtx context.Context, result JobResult, jobLogger logging.L
             "error":         err.Error(),
         }).Warn("Retrying Redis result publishing after backoff")
     }
-    
-    err = retry.WithRetry(jobCtx, retryCfg, func() error {
-        return rdb.RPush(ctx, "stockfish:results", data).Err()
+
+    err := retry.WithRetry(jobCtx, retryCfg, func(attemptCtx context.Context) error {
+        pipe := rdb.Pipeline()
+        pipe.Set(attemptCtx, keys.ResultKey(result.JobID), data, resultTTL)
+        pipe.Publish(attemptCtx, keys.ResultsNotifyChannel, result.JobID)
+        _, err := pipe.Exec(attemptCtx)
+        return err
     })
-    
+
     if err != nil {
         // Log failure after all retries exhausted (Requirement 4.6)
         jobLogger.WithFields(map[string]interface{}{
@@ -617,18 +1170,161 @@ func publishResult(jobCtx context.Context, result JobResult, jobLogger logging.L
             "job_id":         result.JobID,
             "operation":      "redis_result_publish",
         }).Error("Failed to publish result after all retries exhausted", err)
-        return
+        return err
     }
-    
+
     if result.Error != "" {
         jobLogger.WithField("error", result.Error).Warn("Job completed with error")
     } else {
         jobLogger.WithField("bestmove", result.BestMove).Info("Job completed successfully")
     }
+    return nil
 }
 
-// healthCheck implements the health check endpoint
-// Requirement 6.2, 6.3, 6.5: Check Redis and Stockfish connectivity
+// bufferedResult is one result queued for a pipelined flush by the result
+// batcher, carrying everything publishResultDirect needs if that flush fails
+// and the entry has to fall back to the per-result retry path. done reports
+// back to the publishResult call that queued this entry whether it ended up
+// durably published, once flushResultBatch actually resolves it - publishResult
+// blocks on it so the caller never XACKs before that happens.
+type bufferedResult struct {
+    jobCtx    context.Context
+    result    JobResult
+    data      []byte
+    jobLogger logging.Logger
+    done      chan bool
+}
+
+// initResultBatcher reads the opt-in result batching configuration from the
+// environment. Batching is off by default so low-latency deployments keep
+// today's one-SET+PUBLISH-per-result behavior.
+func initResultBatcher() {
+    resultBatchingEnabled = getenv("RESULT_BATCHING_ENABLED", "false") == "true"
+    if !resultBatchingEnabled {
+        return
+    }
+
+    resultFlushBatch = 50
+    if v := os.Getenv("RESULT_FLUSH_BATCH"); v != "" {
+        if n, err := strconv.Atoi(v); err == nil && n > 0 {
+            resultFlushBatch = n
+        }
+    }
+
+    resultFlushInterval = 50 * time.Millisecond
+    if v := os.Getenv("RESULT_FLUSH_INTERVAL"); v != "" {
+        if ms, err := strconv.Atoi(v); err == nil && ms > 0 {
+            resultFlushInterval = time.Duration(ms) * time.Millisecond
+        }
+    }
+
+    resultBatchChan = make(chan *bufferedResult, resultFlushBatch*4)
+    resultBatchStopChan = make(chan struct{})
+    resultBatchStoppedChan = make(chan struct{})
+
+    logger.WithFields(map[string]interface{}{
+        "flush_batch":    resultFlushBatch,
+        "flush_interval": resultFlushInterval.String(),
+    }).Info("Pipelined result publishing enabled")
+}
+
+// runResultBatcher buffers results handed to it by publishResult and flushes
+// them with a single Pipeliner SET+PUBLISH per entry, one Exec(ctx) every
+// resultFlushInterval or once resultFlushBatch entries have accumulated, trading a little
+// latency for far fewer Redis round trips under high throughput.
+func runResultBatcher() {
+    buffer := make([]*bufferedResult, 0, resultFlushBatch)
+    ticker := time.NewTicker(resultFlushInterval)
+    defer ticker.Stop()
+
+    for {
+        select {
+        case br := <-resultBatchChan:
+            buffer = append(buffer, br)
+            if len(buffer) >= resultFlushBatch {
+                buffer = flushResultBatch(buffer)
+            }
+
+        case <-ticker.C:
+            if len(buffer) > 0 {
+                buffer = flushResultBatch(buffer)
+            }
+
+        case <-resultBatchStopChan:
+            // Drain whatever is already queued without blocking, flush one
+            // last time, then tell the shutdown sequence it's safe to close
+            // the Redis connection.
+            for {
+                select {
+                case br := <-resultBatchChan:
+                    buffer = append(buffer, br)
+                default:
+                    if len(buffer) > 0 {
+                        flushResultBatch(buffer)
+                    }
+                    close(resultBatchStoppedChan)
+                    return
+                }
+            }
+        }
+    }
+}
+
+// flushResultBatch SETs and PUBLISHes every buffered entry in one pipeline,
+// then resolves each entry's done channel so the publishResult call blocked
+// on it - and in turn handleJob's XACK - knows the outcome. On pipeline
+// error it falls back to publishResultDirect per entry so a batching
+// failure never costs at-least-once delivery, only the round-trip savings.
+func flushResultBatch(buffer []*bufferedResult) []*bufferedResult {
+    flushStart := time.Now()
+    pipe := rdb.Pipeline()
+    for _, br := range buffer {
+        pipe.Set(ctx, keys.ResultKey(br.result.JobID), br.data, resultTTL)
+        pipe.Publish(ctx, keys.ResultsNotifyChannel, br.result.JobID)
+    }
+    _, err := pipe.Exec(ctx)
+    flushDuration := time.Since(flushStart)
+
+    metricsCol.RecordResultBatchSize(float64(len(buffer)))
+    metricsCol.RecordResultFlushLatency(flushDuration)
+
+    if err != nil {
+        logger.WithFields(map[string]interface{}{
+            "batch_size": len(buffer),
+            "operation":  "redis_result_flush",
+        }).Warn("Pipelined result flush failed, falling back to per-result retry")
+
+        for _, br := range buffer {
+            br.done <- publishResultDirect(br.jobCtx, br.result, br.data, br.jobLogger)
+        }
+        return buffer[:0]
+    }
+
+    for _, br := range buffer {
+        if br.result.Error != "" {
+            br.jobLogger.WithField("error", br.result.Error).Warn("Job completed with error")
+        } else {
+            br.jobLogger.WithField("bestmove", br.result.BestMove).Info("Job completed successfully")
+        }
+        br.done <- true
+    }
+
+    return buffer[:0]
+}
+
+// stopResultBatcher signals the batcher to drain its channel and flush
+// before the caller proceeds with shutdown; a no-op when batching was never
+// enabled.
+func stopResultBatcher() {
+    if !resultBatchingEnabled {
+        return
+    }
+    close(resultBatchStopChan)
+    <-resultBatchStoppedChan
+}
+
+// healthCheck implements the health check endpoint
+// Requirement 6.2, 6.3, 6.5: Check Redis and Stockfish connectivity
 func healthCheck(w http.ResponseWriter, r *http.Request, engineAddr string) {
     type HealthStatus struct {
         Status           string `json:"status"`
@@ -644,8 +1340,11 @@ func healthCheck(w http.ResponseWriter, r *http.Request, engineAddr string) {
     
     redisOk := rdb.Ping(redisCtx).Err() == nil
     
-    // Check Stockfish connectivity with test connection (Requirement 6.3)
-    stockfishOk := checkStockfishHealth(engineAddr, 2*time.Second)
+    // Check Stockfish connectivity with a uci+isready handshake, not just
+    // uci/uciok, so a search thread deadlocked after printing uciok still
+    // fails the check (Requirement 6.3)
+    _, probeErr := probeEngine(engineAddr, ProbeReady, 2*time.Second)
+    stockfishOk := probeErr == nil
     
     status := "healthy"
     statusCode := http.StatusOK
@@ -667,32 +1366,168 @@ func healthCheck(w http.ResponseWriter, r *http.Request, engineAddr string) {
     json.NewEncoder(w).Encode(health)
 }
 
-// checkStockfishHealth verifies engine responsiveness within timeout
-// Requirement 6.3: Verify engine responsiveness within 2 seconds
-func checkStockfishHealth(engineAddr string, timeout time.Duration) bool {
-    conn, err := net.DialTimeout("tcp", engineAddr, timeout)
+// ProbeLevel controls how deep probeEngine's UCI handshake goes.
+type ProbeLevel int
+
+const (
+    // ProbeHandshake sends "uci" and waits for "uciok".
+    ProbeHandshake ProbeLevel = iota
+    // ProbeReady additionally sends "isready" and waits for "readyok", which
+    // catches a search thread that's deadlocked despite having already
+    // printed "uciok".
+    ProbeReady
+    // ProbeSearch additionally runs a depth-1 search and confirms a
+    // "bestmove" line, for a full end-to-end capability check.
+    ProbeSearch
+)
+
+// maxProbeLines bounds how many lines probeEngine will read while waiting
+// for a handshake marker, so a chatty or stuck engine can't block a probe
+// stage past its own deadline via an unbounded read loop.
+const maxProbeLines = 256
+
+// OptionSpec describes one "option name ... type ..." line an engine
+// reported during its uci handshake.
+type OptionSpec struct {
+    Type    string
+    Default string
+}
+
+// EngineInfo is what probeEngine learns about an engine during its
+// handshake, so the worker pool can log engine capabilities and refuse
+// mismatched builds.
+type EngineInfo struct {
+    Name    string
+    Author  string
+    Options map[string]OptionSpec
+}
+
+// probeEngine dials addr and runs a UCI handshake to the depth requested by
+// level, giving each stage its own sub-timeout within the overall timeout
+// instead of relying on bufio.Scanner's unbounded read, so a deadlocked
+// search thread that still manages to print "uciok" doesn't pass the check.
+// Requirement 6.3: Verify engine responsiveness within the health check timeout
+func probeEngine(addr string, level ProbeLevel, timeout time.Duration) (*EngineInfo, error) {
+    overallDeadline := time.Now().Add(timeout)
+    conn, err := net.DialTimeout("tcp", addr, timeout)
     if err != nil {
-        return false
+        return nil, fmt.Errorf("dial %s: %w", addr, err)
     }
     defer conn.Close()
-    
-    // Set read/write deadline
-    conn.SetDeadline(time.Now().Add(timeout))
-    
-    // Send UCI command and wait for "uciok" response
-    _, err = conn.Write([]byte("uci\n"))
+
+    reader := bufio.NewReader(conn)
+    write := func(cmd string) error {
+        _, err := fmt.Fprintf(conn, "%s\n", cmd)
+        return err
+    }
+
+    readLines := func(substr string, stageTimeout time.Duration) ([]string, error) {
+        deadline := time.Now().Add(stageTimeout)
+        var lines []string
+        for time.Now().Before(deadline) && len(lines) < maxProbeLines {
+            conn.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+            line, err := reader.ReadString('\n')
+            if err != nil {
+                if ne, ok := err.(net.Error); ok && ne.Timeout() {
+                    continue
+                }
+                return lines, err
+            }
+            line = strings.TrimSpace(line)
+            lines = append(lines, line)
+            if strings.Contains(line, substr) {
+                return lines, nil
+            }
+        }
+        return lines, fmt.Errorf("timeout waiting for %q", substr)
+    }
+
+    if err := write("uci"); err != nil {
+        return nil, fmt.Errorf("uci: %w", err)
+    }
+    uciLines, err := readLines("uciok", time.Until(overallDeadline))
     if err != nil {
-        return false
+        return nil, fmt.Errorf("uci handshake: %w", err)
     }
-    
-    scanner := bufio.NewScanner(conn)
-    for scanner.Scan() {
-        if strings.Contains(scanner.Text(), "uciok") {
-            return true
+    info := parseEngineInfo(uciLines)
+
+    if level == ProbeHandshake {
+        return info, nil
+    }
+
+    if err := write("isready"); err != nil {
+        return info, fmt.Errorf("isready: %w", err)
+    }
+    if _, err := readLines("readyok", time.Until(overallDeadline)); err != nil {
+        return info, fmt.Errorf("isready handshake: %w", err)
+    }
+
+    if level == ProbeReady {
+        return info, nil
+    }
+
+    if err := write("position startpos"); err != nil {
+        return info, fmt.Errorf("position startpos: %w", err)
+    }
+    if err := write("go depth 1"); err != nil {
+        return info, fmt.Errorf("go depth 1: %w", err)
+    }
+    if _, err := readLines("bestmove", time.Until(overallDeadline)); err != nil {
+        return info, fmt.Errorf("depth-1 search: %w", err)
+    }
+
+    return info, nil
+}
+
+// parseEngineInfo extracts "id name"/"id author" and every advertised
+// option from the lines a UCI engine prints in response to "uci".
+func parseEngineInfo(lines []string) *EngineInfo {
+    info := &EngineInfo{Options: make(map[string]OptionSpec)}
+    for _, line := range lines {
+        switch {
+        case strings.HasPrefix(line, "id name "):
+            info.Name = strings.TrimPrefix(line, "id name ")
+        case strings.HasPrefix(line, "id author "):
+            info.Author = strings.TrimPrefix(line, "id author ")
+        case strings.HasPrefix(line, "option name "):
+            name, spec := parseOptionLine(line)
+            if name != "" {
+                info.Options[name] = spec
+            }
         }
     }
-    
-    return false
+    return info
+}
+
+// parseOptionLine parses a single UCI
+// "option name <name> type <type> [default <default> ...]" line.
+func parseOptionLine(line string) (string, OptionSpec) {
+    fields := strings.Fields(line)
+    var name, typ, def string
+    for i := 0; i < len(fields); i++ {
+        switch fields[i] {
+        case "name":
+            var nameParts []string
+            for i++; i < len(fields) && fields[i] != "type"; i++ {
+                nameParts = append(nameParts, fields[i])
+            }
+            name = strings.Join(nameParts, " ")
+            i--
+        case "type":
+            if i+1 < len(fields) {
+                typ = fields[i+1]
+                i++
+            }
+        case "default":
+            var defParts []string
+            for i++; i < len(fields) && fields[i] != "min" && fields[i] != "max" && fields[i] != "var"; i++ {
+                defParts = append(defParts, fields[i])
+            }
+            def = strings.Join(defParts, " ")
+            i--
+        }
+    }
+    return name, OptionSpec{Type: typ, Default: def}
 }
 
 func getenv(k, def string) string {
@@ -713,6 +1548,9 @@ func trackCPUAndEfficiency() {
     // Initialize last CPU time
     cpuTrackingMu.Lock()
     lastCPUTime = getCPUTime()
+    if times, err := cpuTimeSource.Times(); err == nil {
+        lastCPUTimes = times
+    }
     cpuTrackingMu.Unlock()
     
     for {
@@ -721,56 +1559,158 @@ func trackCPUAndEfficiency() {
             return
         case <-ticker.C:
             updateCPUMetrics()
+            updateCgroupMetrics()
         }
     }
 }
 
-// updateCPUMetrics updates CPU consumption and cost efficiency metrics
+// updateCgroupMetrics reads this process's cgroup CPU accounting and PSI
+// pressure, publishing throttling counters and pressure gauges. A host
+// without a cgroup CPU controller (no container runtime, or a v1 host
+// missing cpu.stat) just means these files don't exist, so this is a silent
+// no-op rather than an error.
+// Requirement 5.2: Track CPU-seconds consumed using container metrics
+func updateCgroupMetrics() {
+    if stat, err := cgroupstat.ReadStat(); err == nil {
+        cgroupTrackingMu.Lock()
+        if stat.ThrottledUsec >= lastThrottledUsec {
+            metricsCol.IncrementCPUThrottledSeconds(float64(stat.ThrottledUsec-lastThrottledUsec) / 1e6)
+        }
+        if stat.NrThrottled >= lastThrottleEvents {
+            metricsCol.IncrementCPUThrottleEvents(float64(stat.NrThrottled - lastThrottleEvents))
+        }
+        lastThrottledUsec = stat.ThrottledUsec
+        lastThrottleEvents = stat.NrThrottled
+        cgroupTrackingMu.Unlock()
+    }
+
+    if pressure, err := cgroupstat.ReadPressure(); err == nil {
+        metricsCol.SetCPUPressureAvg10(pressure.Some.Avg10)
+    }
+}
+
+// updateCPUMetrics updates CPU consumption and cost efficiency metrics,
+// broken down by class the way runtime/metrics' /cpu/classes/... tree does
+// Requirement 5.2: Track CPU-seconds consumed using container metrics
 func updateCPUMetrics() {
     cpuTrackingMu.Lock()
     defer cpuTrackingMu.Unlock()
-    
-    currentCPUTime := getCPUTime()
+
+    currentTimes, err := cpuTimeSource.Times()
+    if err != nil {
+        logger.WithField("error", err.Error()).Warn("Failed to read process CPU time")
+        return
+    }
+    currentCPUTime := currentTimes.Total
     if currentCPUTime == 0 {
         return
     }
-    
+
+    // Split procfs utime/stime into separate counters instead of collapsing
+    // them into one total.
+    if userDelta := currentTimes.User - lastCPUTimes.User; userDelta > 0 {
+        metricsCol.IncrementCPUSecondsUser(userDelta.Seconds())
+    }
+    if systemDelta := currentTimes.System - lastCPUTimes.System; systemDelta > 0 {
+        metricsCol.IncrementCPUSecondsSystem(systemDelta.Seconds())
+    }
+
+    samples := make([]rtmetrics.Sample, len(cpuClassSamples))
+    copy(samples, cpuClassSamples)
+    rtmetrics.Read(samples)
+
+    var gcTotalSeconds, gcPauseSeconds, idleSeconds, scavengeSeconds float64
+    var goroutines uint64
+    for _, s := range samples {
+        switch s.Name {
+        case "/cpu/classes/gc/total:cpu-seconds":
+            gcTotalSeconds = s.Value.Float64()
+        case "/cpu/classes/gc/pause:cpu-seconds":
+            gcPauseSeconds = s.Value.Float64()
+        case "/cpu/classes/idle:cpu-seconds":
+            idleSeconds = s.Value.Float64()
+        case "/cpu/classes/scavenge/total:cpu-seconds":
+            scavengeSeconds = s.Value.Float64()
+        case "/sched/goroutines:goroutines":
+            goroutines = s.Value.Uint64()
+        }
+    }
+
+    if delta := gcTotalSeconds - lastGCTotalSeconds; delta > 0 {
+        metricsCol.IncrementCPUSecondsGCTotal(delta)
+    }
+    if delta := gcPauseSeconds - lastGCPauseSeconds; delta > 0 {
+        metricsCol.IncrementCPUSecondsGCPause(delta)
+    }
+    if delta := idleSeconds - lastIdleSeconds; delta > 0 {
+        metricsCol.IncrementCPUSecondsIdle(delta)
+    }
+    if delta := scavengeSeconds - lastScavengeSeconds; delta > 0 {
+        metricsCol.IncrementCPUSecondsScavenge(delta)
+    }
+
+    logger.WithFields(map[string]interface{}{
+        "cpu_seconds_gc_total": gcTotalSeconds,
+        "cpu_seconds_idle":     idleSeconds,
+        "goroutines":           goroutines,
+    }).Debug("CPU time class breakdown")
+
     // Calculate CPU seconds consumed since last check
     cpuDelta := currentCPUTime - lastCPUTime
     if cpuDelta > 0 {
         cpuSeconds := cpuDelta.Seconds()
-        metricsCol.IncrementCPUSeconds(cpuSeconds)
-        
-        // Calculate cost efficiency ratio (operations / CPU-seconds)
+        // Jobs arriving on the shared queue don't carry a tenant identity
+        // today (see pkg/metrics/tenantstats), so this process-wide CPU
+        // accounting is attributed to the untagged "" tenant rather than
+        // fabricating a per-job breakdown the worker can't actually observe.
+        metricsCol.IncrementCPUSeconds("", cpuSeconds)
+
+        // Calculate cost efficiency ratio (operations / non-GC, non-idle
+        // CPU-seconds) so background GC work doesn't inflate the cost
+        // attributed to chess evaluations.
         // Requirement 5.3: Calculate cost efficiency ratio
         ops := atomic.LoadInt64(&totalOperations)
-        if cpuSeconds > 0 && ops > 0 {
-            // Calculate efficiency as operations per CPU-second
-            totalCPUSeconds := currentCPUTime.Seconds()
-            if totalCPUSeconds > 0 {
-                efficiency := float64(ops) / totalCPUSeconds
-                metricsCol.SetCostEfficiency(efficiency)
-            }
+        effectiveCPUSeconds := currentCPUTime.Seconds() - gcTotalSeconds - idleSeconds
+        if effectiveCPUSeconds > 0 && ops > 0 {
+            efficiency := float64(ops) / effectiveCPUSeconds
+            metricsCol.SetCostEfficiency("", efficiency)
         }
-        
-        lastCPUTime = currentCPUTime
     }
+
+    lastCPUTime = currentCPUTime
+    lastCPUTimes = currentTimes
+    lastGCTotalSeconds = gcTotalSeconds
+    lastGCPauseSeconds = gcPauseSeconds
+    lastIdleSeconds = idleSeconds
+    lastScavengeSeconds = scavengeSeconds
 }
 
-// recordIdleTime records idle time for percentage calculation
-// Requirement 5.5: Track time spent waiting for jobs vs processing
-func recordIdleTime(duration time.Duration) {
+// recordIdleTime appends an idle sample covering [start, now) and nudges
+// idleEWMA towards 100%. Requirement 5.5: Track time spent waiting for jobs
+// vs processing
+func recordIdleTime(start time.Time) {
+    duration := time.Since(start)
+    if duration <= 0 {
+        return
+    }
     idleTrackingMu.Lock()
     defer idleTrackingMu.Unlock()
-    totalIdleTime += duration
+    idleSamples = append(idleSamples, idleSample{at: time.Now(), idleDelta: duration})
+    idleEWMA = idleEWMAAlpha*100.0 + (1-idleEWMAAlpha)*idleEWMA
 }
 
-// recordProcessingTime records processing time for percentage calculation
-// Requirement 5.5: Track time spent waiting for jobs vs processing
-func recordProcessingTime(duration time.Duration) {
+// recordProcessingTime appends a processing sample covering [start, now) and
+// nudges idleEWMA towards 0%. Requirement 5.5: Track time spent waiting for
+// jobs vs processing
+func recordProcessingTime(start time.Time) {
+    duration := time.Since(start)
+    if duration <= 0 {
+        return
+    }
     idleTrackingMu.Lock()
     defer idleTrackingMu.Unlock()
-    totalProcessTime += duration
+    idleSamples = append(idleSamples, idleSample{at: time.Now(), processDelta: duration})
+    idleEWMA = idleEWMAAlpha*0.0 + (1-idleEWMAAlpha)*idleEWMA
 }
 
 // trackIdlePercentage periodically calculates and exposes idle percentage
@@ -789,87 +1729,304 @@ func trackIdlePercentage() {
     }
 }
 
-// calculateIdlePercentage calculates the idle percentage
+// idleWindows are the trailing windows calculateIdlePercentage reports,
+// mirroring the 1/5/15-minute load-average convention gopsutil and telegraf
+// use for process stats.
+var idleWindows = []struct {
+    label string
+    dur   time.Duration
+}{
+    {"1m", time.Minute},
+    {"5m", 5 * time.Minute},
+    {"15m", 15 * time.Minute},
+}
+
+// calculateIdlePercentage prunes samples older than idleWindowMax and, for
+// each window in idleWindows, sums the idle/process deltas that fall inside
+// it to compute a trailing idle percentage - so a worker that was idle for
+// hours before a sustained burst of jobs reflects the burst within minutes
+// instead of staying near 100% for its whole lifetime.
 // Requirement 5.5: Calculate idle percentage
 func calculateIdlePercentage() {
     idleTrackingMu.Lock()
     defer idleTrackingMu.Unlock()
-    
-    // Calculate total uptime
-    totalUptime := time.Since(workerStartTime)
-    if totalUptime == 0 {
-        return
+
+    now := time.Now()
+    cutoff := now.Add(-idleWindowMax)
+    pruned := idleSamples[:0]
+    for _, s := range idleSamples {
+        if s.at.After(cutoff) {
+            pruned = append(pruned, s)
+        }
     }
-    
-    // Calculate idle percentage
-    // Idle percentage = (idle time / total uptime) * 100
-    idlePercentage := (float64(totalIdleTime) / float64(totalUptime)) * 100.0
-    
-    // Ensure percentage is between 0 and 100
-    if idlePercentage < 0 {
-        idlePercentage = 0
-    } else if idlePercentage > 100 {
-        idlePercentage = 100
+    idleSamples = pruned
+
+    metricsCol.SetIdlePercentage(idleEWMA)
+
+    fields := map[string]interface{}{"idle_ewma": idleEWMA}
+    for _, w := range idleWindows {
+        windowStart := now.Add(-w.dur)
+        var idle, processed time.Duration
+        for _, s := range idleSamples {
+            if s.at.Before(windowStart) {
+                continue
+            }
+            idle += s.idleDelta
+            processed += s.processDelta
+        }
+
+        total := idle + processed
+        if total <= 0 {
+            continue
+        }
+
+        idlePercentage := (float64(idle) / float64(total)) * 100.0
+        if idlePercentage < 0 {
+            idlePercentage = 0
+        } else if idlePercentage > 100 {
+            idlePercentage = 100
+        }
+
+        metricsCol.SetIdlePercentageWindow(w.label, idlePercentage)
+        fields["idle_percentage_"+w.label] = idlePercentage
     }
-    
-    // Expose idle percentage metric (Requirement 5.5)
-    metricsCol.SetIdlePercentage(idlePercentage)
-    
-    // Log idle statistics periodically for debugging
-    logger.WithFields(map[string]interface{}{
-        "idle_percentage":    idlePercentage,
-        "total_idle_seconds": totalIdleTime.Seconds(),
-        "total_process_seconds": totalProcessTime.Seconds(),
-        "total_uptime_seconds": totalUptime.Seconds(),
-    }).Info("Idle time statistics")
+
+    logger.WithFields(fields).Info("Idle time statistics")
 }
 
-// getCPUTime reads the current CPU time from /proc/self/stat
-// This returns the total CPU time (user + system) consumed by the process
+// getCPUTime returns the total CPU time (user + system) consumed by the
+// process so far, via pkg/cputime's Source for the running platform.
 // Requirement 5.2: Track CPU-seconds consumed using container metrics
 func getCPUTime() time.Duration {
-    // Read /proc/self/stat which contains process CPU usage
-    data, err := ioutil.ReadFile("/proc/self/stat")
+    times, err := cpuTimeSource.Times()
     if err != nil {
-        // If we can't read proc stats (e.g., not on Linux), return 0
+        logger.WithField("error", err.Error()).Warn("Failed to read process CPU time")
         return 0
     }
-    
-    // Parse the stat file
-    // Format: pid (comm) state ppid pgrp session tty_nr tpgid flags minflt cminflt majflt cmajflt utime stime ...
-    // We need fields 14 (utime) and 15 (stime) which are in clock ticks
-    fields := strings.Fields(string(data))
-    if len(fields) < 15 {
-        return 0
+    return times.Total
+}
+// jobStatusHandler serves GET /jobs/{id}, returning the tracked jobstats
+// hash for a job so operators can inspect its lifecycle state directly
+func jobStatusHandler(w http.ResponseWriter, r *http.Request) {
+    jobID := strings.TrimPrefix(r.URL.Path, "/jobs/")
+    if jobID == "" {
+        http.Error(w, "missing job id", http.StatusBadRequest)
+        return
+    }
+
+    reqCtx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
+    defer cancel()
+
+    state, err := jobStats.Get(reqCtx, jobID)
+    if err == redis.Nil {
+        http.Error(w, "job not found", http.StatusNotFound)
+        return
     }
-    
-    // Parse utime (user mode CPU time in clock ticks)
-    utime, err := strconv.ParseInt(fields[13], 10, 64)
     if err != nil {
-        return 0
+        logger.WithField("job_id", jobID).Error("Failed to fetch job state", err)
+        http.Error(w, "internal error", http.StatusInternalServerError)
+        return
     }
-    
-    // Parse stime (kernel mode CPU time in clock ticks)
-    stime, err := strconv.ParseInt(fields[14], 10, 64)
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(state)
+}
+
+// recoverStaleJobs runs once at startup, requeuing jobs this worker's
+// previous incarnation left running when it crashed, as well as jobs
+// abandoned by any worker whose heartbeat has gone silent
+func recoverStaleJobs() {
+    stale, err := jobStats.RecoverStale(ctx, staleJobTimeout)
     if err != nil {
-        return 0
+        logger.Error("Failed to scan for stale jobs", err)
+        return
     }
-    
-    // Total CPU time in clock ticks
-    totalTicks := utime + stime
-    
-    // Convert clock ticks to duration
-    // Clock ticks per second is typically 100 (USER_HZ)
-    clockTicksPerSecond := int64(100)
-    
-    // Try to read actual clock ticks per second from system
-    if clkTck := os.Getenv("CLK_TCK"); clkTck != "" {
-        if val, err := strconv.ParseInt(clkTck, 10, 64); err == nil && val > 0 {
-            clockTicksPerSecond = val
+
+    for _, state := range stale {
+        staleLogger := logger.WithFields(map[string]interface{}{
+            "job_id":    state.JobID,
+            "worker_id": state.WorkerID,
+            "status":    string(state.Status),
+        })
+
+        if err := json.Unmarshal([]byte(state.Payload), &Job{}); err != nil {
+            staleLogger.Error("Stale job payload unreadable, publishing failure", err)
+            result := JobResult{
+                JobID:       state.JobID,
+                Error:       "abandoned: owning worker crashed and payload was unreadable",
+                CompletedAt: time.Now().Format(time.RFC3339Nano),
+            }
+            publishResult(ctx, result, staleLogger)
+            jobStats.Complete(ctx, state.JobID, jobstats.StatusFailed, result.Error)
+            continue
         }
+
+        queueKey := keys.JobsQueue(keys.ShardForJobID(state.JobID))
+        err := rdb.XAdd(ctx, &redis.XAddArgs{
+            Stream: queueKey,
+            Values: map[string]interface{}{"job": state.Payload},
+        }).Err()
+        if err != nil {
+            staleLogger.Error("Failed to requeue stale job", err)
+            continue
+        }
+
+        // Mark the job requeued so it drops out of the running/pending set
+        // RecoverStale's own scan matches against - otherwise the next scan
+        // still sees the same dead WorkerID and stale heartbeat and requeues
+        // it again, duplicating it once per recovery scan until some worker
+        // finally claims it.
+        if err := jobStats.MarkRequeued(ctx, state.JobID); err != nil {
+            staleLogger.Error("Failed to mark stale job requeued", err)
+        }
+
+        staleLogger.Info("Requeued stale job abandoned by a crashed worker")
     }
-    
-    // Convert to nanoseconds
-    nanoseconds := (totalTicks * 1e9) / clockTicksPerSecond
-    return time.Duration(nanoseconds)
-}
\ No newline at end of file
+}
+
+// trackAuditLogBufferSize periodically reports auditWriter's backlog
+// (AuditLogWriter.GetBufferSize()). StdoutAuditWriter writes synchronously
+// and always reports 0, so with it this gauge stays at 0 and does not show
+// the mux channel in front of the writer filling up.
+func trackAuditLogBufferSize() {
+    ticker := time.NewTicker(10 * time.Second)
+    defer ticker.Stop()
+
+    for {
+        select {
+        case <-shutdownChan:
+            return
+        case <-ticker.C:
+            metricsCol.SetAuditLogBufferSize("worker", "stdout", float64(auditWriter.GetBufferSize()))
+        }
+    }
+}
+
+// trackJobStats periodically republishes jobs-by-status gauges from the
+// jobstats active index so Prometheus reflects the current lifecycle mix
+func trackJobStats() {
+    ticker := time.NewTicker(10 * time.Second)
+    defer ticker.Stop()
+
+    for {
+        select {
+        case <-shutdownChan:
+            return
+        case <-ticker.C:
+            counts, err := jobStats.CountByStatus(ctx)
+            if err != nil {
+                continue
+            }
+            for status, count := range counts {
+                metricsCol.SetJobsByStatus(string(status), float64(count))
+            }
+        }
+    }
+}
+
+// jobControlEntry tracks the cancellation hook and, once established, the
+// live Stockfish connection for a single in-flight job
+type jobControlEntry struct {
+    cancel context.CancelFunc
+
+    mu              sync.Mutex
+    conn            net.Conn
+    cancelRequested bool
+}
+
+var (
+    jobControlRegistry   = make(map[string]*jobControlEntry)
+    jobControlRegistryMu sync.Mutex
+)
+
+// registerJobControl makes jobID's cancel func reachable from the pub/sub
+// listener for the duration of handleJob
+func registerJobControl(jobID string, cancel context.CancelFunc) *jobControlEntry {
+    entry := &jobControlEntry{cancel: cancel}
+
+    jobControlRegistryMu.Lock()
+    jobControlRegistry[jobID] = entry
+    jobControlRegistryMu.Unlock()
+
+    return entry
+}
+
+// unregisterJobControl removes jobID once handleJob has finished with it
+func unregisterJobControl(jobID string) {
+    jobControlRegistryMu.Lock()
+    delete(jobControlRegistry, jobID)
+    jobControlRegistryMu.Unlock()
+}
+
+// attachConn records the live Stockfish connection for this job. If a cancel
+// arrived before the connection was established, it is applied now instead
+// of being lost to the race.
+func (e *jobControlEntry) attachConn(conn net.Conn) {
+    e.mu.Lock()
+    defer e.mu.Unlock()
+
+    e.conn = conn
+    if e.cancelRequested {
+        writeStopCommand(conn)
+        e.cancel()
+    }
+}
+
+// requestCancel writes UCI "stop" to the live connection (if any) and
+// cancels the job's context. If no connection has been attached yet, the
+// request is buffered and replayed by attachConn.
+func (e *jobControlEntry) requestCancel() {
+    e.mu.Lock()
+    defer e.mu.Unlock()
+
+    e.cancelRequested = true
+    if e.conn != nil {
+        writeStopCommand(e.conn)
+    }
+    e.cancel()
+}
+
+func writeStopCommand(conn net.Conn) {
+    fmt.Fprintf(conn, "stop\n")
+}
+
+// subscribeJobControl listens for cancellation signals on every shard's
+// control channel and forwards them to whichever job is registered locally,
+// for the life of the worker process
+func subscribeJobControl() {
+    pubsub := rdb.PSubscribe(ctx, keys.ControlChannelPattern)
+    defer pubsub.Close()
+
+    ch := pubsub.Channel()
+    for {
+        select {
+        case <-shutdownChan:
+            return
+        case msg, ok := <-ch:
+            if !ok {
+                return
+            }
+            handleJobControlMessage(msg)
+        }
+    }
+}
+
+// handleJobControlMessage looks up the job named by the control channel and
+// applies the requested cancellation if this worker currently owns it
+func handleJobControlMessage(msg *redis.Message) {
+    jobID := keys.JobIDFromControlChannel(msg.Channel)
+    if jobID == "" || (msg.Payload != "cancel" && msg.Payload != "stop") {
+        return
+    }
+
+    jobControlRegistryMu.Lock()
+    entry, ok := jobControlRegistry[jobID]
+    jobControlRegistryMu.Unlock()
+    if !ok {
+        // Not claimed by this worker (or already finished); nothing to do
+        return
+    }
+
+    logger.WithField("job_id", jobID).Info("Received job cancellation signal")
+    entry.requestCancel()
+}